@@ -0,0 +1,70 @@
+package observability
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+// authCredentials are the credentials accepted by an endpoint. Any configured scheme grants
+// access; with none configured the endpoint is open.
+type authCredentials struct {
+	Username string
+	Password string
+	Token    string
+}
+
+// isSet returns true if any scheme is configured
+func (c authCredentials) isSet() bool {
+	return c.Username != "" || c.Token != ""
+}
+
+// metricsAuth returns the credentials required by the Prometheus scrape endpoint
+func (b *BaseConfig) metricsAuth() authCredentials {
+	return authCredentials{
+		Username: strings.TrimSpace(b.MetricsAuthUsername),
+		Password: string(b.MetricsAuthPassword),
+		Token:    string(b.MetricsAuthToken),
+	}
+}
+
+// requireAuth rejects requests carrying neither matching basic auth credentials nor an
+// "Authorization: Bearer <token>" header matching the token, answering 401 with a challenge per
+// configured scheme
+func requireAuth(realm string, creds authCredentials, next http.Handler) http.Handler {
+	if !creds.isSet() {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if creds.allows(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if creds.Username != "" {
+			w.Header().Add("WWW-Authenticate", `Basic realm="`+realm+`"`)
+		}
+		if creds.Token != "" {
+			w.Header().Add("WWW-Authenticate", `Bearer realm="`+realm+`"`)
+		}
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+	})
+}
+
+// allows reports whether r carries valid credentials, comparing in constant time
+func (c authCredentials) allows(r *http.Request) bool {
+	if c.Token != "" {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if ok && subtle.ConstantTimeCompare([]byte(token), []byte(c.Token)) == 1 {
+			return true
+		}
+	}
+	if c.Username != "" {
+		user, pass, ok := r.BasicAuth()
+		userOK := subtle.ConstantTimeCompare([]byte(user), []byte(c.Username)) == 1
+		passOK := subtle.ConstantTimeCompare([]byte(pass), []byte(c.Password)) == 1
+		if ok && userOK && passOK {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,142 @@
+package observability
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestRequireAuth(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+	both := authCredentials{Username: "prometheus", Password: "pa55", Token: "s3cret"}
+
+	tests := []struct {
+		name  string
+		creds authCredentials
+		setup func(r *http.Request)
+		want  int
+	}{
+		{name: "Open", creds: authCredentials{}, setup: func(*http.Request) {}, want: http.StatusOK},
+		{name: "Valid Token", creds: both, setup: func(r *http.Request) { r.Header.Set("Authorization", "Bearer s3cret") }, want: http.StatusOK},
+		{name: "Valid Basic", creds: both, setup: func(r *http.Request) { r.SetBasicAuth("prometheus", "pa55") }, want: http.StatusOK},
+		{name: "Missing Header", creds: both, setup: func(*http.Request) {}, want: http.StatusUnauthorized},
+		{name: "Wrong Token", creds: both, setup: func(r *http.Request) { r.Header.Set("Authorization", "Bearer other") }, want: http.StatusUnauthorized},
+		{name: "Wrong Password", creds: both, setup: func(r *http.Request) { r.SetBasicAuth("prometheus", "other") }, want: http.StatusUnauthorized},
+		{name: "Token As Basic Password", creds: authCredentials{Token: "s3cret"}, setup: func(r *http.Request) { r.SetBasicAuth("prometheus", "s3cret") }, want: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			tt.setup(req)
+			rec := httptest.NewRecorder()
+			requireAuth("metrics", tt.creds, ok).ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("expected status %d, got %d", tt.want, rec.Code)
+			}
+			if rec.Code == http.StatusUnauthorized && len(rec.Header().Values("WWW-Authenticate")) == 0 {
+				t.Error("expected a WWW-Authenticate challenge")
+			}
+		})
+	}
+}
+
+func TestNewObservability_MetricsAuth(t *testing.T) {
+	o, err := NewObservability(BaseConfig{
+		ServiceName:         "test-otel-auth",
+		Version:             "1.0.0",
+		TracingDisabled:     true,
+		MetricsMode:         "pull",
+		MetricsHost:         "127.0.0.1",
+		MetricsPath:         "/metrics",
+		MetricsPort:         0,
+		MetricsAuthUsername: "prometheus",
+		MetricsAuthPassword: "pa55",
+		BuildInfoEnabled:    true,
+		OtelDisableGlobals:  true,
+	})
+	if err != nil {
+		t.Fatalf("NewObservability failed: %v", err)
+	}
+	defer func() { _ = o.Shutdown(context.Background()) }()
+
+	get := func(path string, auth bool) int {
+		req, _ := http.NewRequest(http.MethodGet, "http://"+o.MetricsAddr()+path, nil)
+		if auth {
+			req.SetBasicAuth("prometheus", "pa55")
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("failed to get %s: %v", path, err)
+		}
+		_ = resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	tests := []struct {
+		path string
+		auth bool
+		want int
+	}{
+		{path: "/metrics", auth: false, want: http.StatusUnauthorized},
+		{path: "/metrics", auth: true, want: http.StatusOK},
+		// Debug endpoints without their own token fall back to the metrics credentials
+		{path: BuildInfoPath, auth: false, want: http.StatusUnauthorized},
+		{path: BuildInfoPath, auth: true, want: http.StatusOK},
+		// Probes cannot send credentials
+		{path: ReadinessPath, auth: false, want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		if code := get(tt.path, tt.auth); code != tt.want {
+			t.Errorf("GET %s (auth %v): expected %d, got %d", tt.path, tt.auth, tt.want, code)
+		}
+	}
+	if addr := o.MetricsAddr(); !strings.HasPrefix(addr, "127.0.0.1:") {
+		t.Errorf("expected the server bound to METRICS_HOST, got %s", addr)
+	}
+}
+
+func TestLoadCfgMetricsAuth(t *testing.T) {
+	defer func() {
+		_ = os.Unsetenv("METRICS_AUTH_USERNAME")
+		_ = os.Unsetenv("METRICS_AUTH_PASSWORD")
+		_ = os.Unsetenv("METRICS_AUTH_TOKEN")
+	}()
+
+	_ = os.Unsetenv("LOG_LEVEL")
+	_ = os.Setenv("SERVICE_NAME", "auth-service")
+	_ = os.Setenv("METRICS_MODE", "pull")
+
+	tests := []struct {
+		name     string
+		username string
+		password string
+		token    string
+		wantErr  bool
+	}{
+		{name: "Basic", username: "prometheus", password: "pa55", wantErr: false},
+		{name: "Bearer", token: "s3cret", wantErr: false},
+		{name: "Username Without Password", username: "prometheus", wantErr: true},
+		{name: "Password Without Username", password: "pa55", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_ = os.Setenv("METRICS_AUTH_USERNAME", tt.username)
+			_ = os.Setenv("METRICS_AUTH_PASSWORD", tt.password)
+			_ = os.Setenv("METRICS_AUTH_TOKEN", tt.token)
+
+			var cfg BaseConfig
+			err := LoadCfg(&cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadCfg() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (string(cfg.MetricsAuthToken) != tt.token || cfg.MetricsHost != "0.0.0.0") {
+				t.Errorf("unexpected config %+v", cfg)
+			}
+		})
+	}
+}
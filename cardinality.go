@@ -0,0 +1,278 @@
+package observability
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	prom "github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/otlptranslator"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// overflowKey marks the series the SDK aggregates attribute sets beyond the cardinality limit into
+const overflowKey = attribute.Key("otel.metric.overflow")
+
+// overflowLabel is overflowKey as a Prometheus label
+const overflowLabel = "otel_metric_overflow"
+
+// cardinalityWarnInterval is the minimum time between two warnings for the same instrument
+const cardinalityWarnInterval = time.Minute
+
+// cardinalityMonitor reports instruments whose attribute sets exceeded the cardinality limit. It
+// inspects exported data for the overflow series, counts each export containing one and logs a
+// warning at most once per cardinalityWarnInterval per instrument.
+type cardinalityMonitor struct {
+	logger *Logger
+	limit  int
+
+	mu        sync.Mutex
+	overflows metric.Int64Counter
+	lastWarn  map[string]time.Time
+	now       func() time.Time
+	// promNames maps Prometheus family names to the metric names seen by push exporters
+	promNames map[string]string
+}
+
+// newCardinalityMonitor returns a monitor for the given limit, or nil if the limit is disabled
+func newCardinalityMonitor(logger *Logger, limit int) *cardinalityMonitor {
+	if limit <= 0 {
+		return nil
+	}
+	return &cardinalityMonitor{
+		logger:    logger,
+		limit:     limit,
+		lastWarn:  make(map[string]time.Time),
+		now:       time.Now,
+		promNames: make(map[string]string),
+	}
+}
+
+// setMeterProvider creates the self-metric once the MeterProvider exists
+func (m *cardinalityMonitor) setMeterProvider(mp metric.MeterProvider) {
+	counter, err := mp.Meter("cardinality").Int64Counter("metric.cardinality.overflow",
+		metric.WithDescription("Number of metric exports in which an instrument exceeded the cardinality limit"),
+	)
+	if err != nil {
+		m.logger.Warn("failed to create metric.cardinality.overflow counter", "error", err)
+		return
+	}
+	m.mu.Lock()
+	m.overflows = counter
+	m.mu.Unlock()
+}
+
+// report records that instrument has an overflow series in the current export
+func (m *cardinalityMonitor) report(ctx context.Context, instrument string) {
+	m.mu.Lock()
+	counter := m.overflows
+	now := m.now()
+	warn := now.Sub(m.lastWarn[instrument]) >= cardinalityWarnInterval
+	if warn {
+		m.lastWarn[instrument] = now
+	}
+	m.mu.Unlock()
+
+	if counter != nil {
+		counter.Add(ctx, 1, metric.WithAttributes(attribute.String("metric.name", instrument)))
+	}
+	if warn {
+		m.logger.Warn("Metric cardinality limit exceeded",
+			"instrument", instrument,
+			"limit", m.limit,
+			"hint", "attribute sets beyond the limit are aggregated into the otel.metric.overflow=true series",
+		)
+	}
+}
+
+// checkResourceMetrics reports every instrument of rm with an overflow series
+func (m *cardinalityMonitor) checkResourceMetrics(ctx context.Context, rm *metricdata.ResourceMetrics) {
+	for _, sm := range rm.ScopeMetrics {
+		for _, met := range sm.Metrics {
+			if hasOverflowSeries(met.Data) {
+				m.report(ctx, met.Name)
+			}
+		}
+	}
+}
+
+// exporter wraps exp so every exported batch is checked for overflow series
+func (m *cardinalityMonitor) exporter(exp sdkmetric.Exporter) sdkmetric.Exporter {
+	return &overflowExporter{Exporter: exp, monitor: m}
+}
+
+// gatherer wraps g so every Prometheus scrape is checked for overflow series. Overflows are
+// reported under the metric name, as in push mode, rather than the Prometheus family name.
+func (m *cardinalityMonitor) gatherer(g prom.Gatherer) prom.Gatherer {
+	return prom.GathererFunc(func() ([]*dto.MetricFamily, error) {
+		families, err := g.Gather()
+		for _, f := range families {
+			if familyHasOverflow(f) {
+				m.report(context.Background(), m.metricName(f.GetName()))
+			}
+		}
+		return families, err
+	})
+}
+
+// view returns a View that never matches and is only used for its side effect. In pull mode the
+// Prometheus exporter collects internally, so no OTel metric data, and no metric name, ever
+// reaches this package; scrapes only carry family names. The SDK passes every instrument to every
+// view when the instrument is created, so this view records the family name of each stream the
+// instrument produces, to map scrapes back to metric names.
+//
+// It applies views itself to follow renames, the way the SDK does regardless of order: each
+// matching view yields a stream, named by the view or after the instrument, and an instrument
+// matched by none keeps its name. This runs views once more per instrument creation, never per
+// measurement or collection.
+func (m *cardinalityMonitor) view(views []sdkmetric.View) sdkmetric.View {
+	return func(inst sdkmetric.Instrument) (sdkmetric.Stream, bool) {
+		streams := []sdkmetric.Stream{{Name: inst.Name, Unit: inst.Unit}}
+		matched := false
+		for _, v := range views {
+			stream, ok := v(inst)
+			if !ok {
+				continue
+			}
+			if stream.Name == "" {
+				stream.Name = inst.Name
+			}
+			if stream.Unit == "" {
+				stream.Unit = inst.Unit
+			}
+			if !matched {
+				streams, matched = streams[:0], true
+			}
+			streams = append(streams, stream)
+		}
+
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		for _, s := range streams {
+			if family := promFamilyName(s.Name, s.Unit, inst.Kind); family != "" {
+				m.promNames[family] = s.Name
+			}
+		}
+		return sdkmetric.Stream{}, false
+	}
+}
+
+// metricName returns the metric name exposed as the Prometheus family, or family if unknown
+func (m *cardinalityMonitor) metricName(family string) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if name, ok := m.promNames[family]; ok {
+		return name
+	}
+	return family
+}
+
+// promFamilyName returns the family name the Prometheus exporter uses for a metric, or "" if it
+// cannot be translated
+func promFamilyName(name, unit string, kind sdkmetric.InstrumentKind) string {
+	var metricType otlptranslator.MetricType = otlptranslator.MetricTypeGauge
+	switch kind {
+	case sdkmetric.InstrumentKindCounter, sdkmetric.InstrumentKindObservableCounter:
+		metricType = otlptranslator.MetricTypeMonotonicCounter
+	case sdkmetric.InstrumentKindUpDownCounter, sdkmetric.InstrumentKindObservableUpDownCounter:
+		metricType = otlptranslator.MetricTypeNonMonotonicCounter
+	case sdkmetric.InstrumentKindHistogram:
+		metricType = otlptranslator.MetricTypeHistogram
+	}
+
+	namer := otlptranslator.NewMetricNamer("", otlptranslator.UnderscoreEscapingWithSuffixes)
+	family, err := namer.Build(otlptranslator.Metric{Name: name, Unit: unit, Type: metricType})
+	if err != nil {
+		return ""
+	}
+	return family
+}
+
+// overflowExporter checks exported data for overflow series before passing it on
+type overflowExporter struct {
+	sdkmetric.Exporter
+	monitor *cardinalityMonitor
+}
+
+// Export reports overflowing instruments and exports rm
+func (e *overflowExporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	e.monitor.checkResourceMetrics(ctx, rm)
+	return e.Exporter.Export(ctx, rm)
+}
+
+// hasOverflowSeries reports whether any data point of data carries the overflow attribute
+func hasOverflowSeries(data metricdata.Aggregation) bool {
+	switch d := data.(type) {
+	case metricdata.Sum[int64]:
+		for _, dp := range d.DataPoints {
+			if isOverflowSet(dp.Attributes) {
+				return true
+			}
+		}
+	case metricdata.Sum[float64]:
+		for _, dp := range d.DataPoints {
+			if isOverflowSet(dp.Attributes) {
+				return true
+			}
+		}
+	case metricdata.Gauge[int64]:
+		for _, dp := range d.DataPoints {
+			if isOverflowSet(dp.Attributes) {
+				return true
+			}
+		}
+	case metricdata.Gauge[float64]:
+		for _, dp := range d.DataPoints {
+			if isOverflowSet(dp.Attributes) {
+				return true
+			}
+		}
+	case metricdata.Histogram[int64]:
+		for _, dp := range d.DataPoints {
+			if isOverflowSet(dp.Attributes) {
+				return true
+			}
+		}
+	case metricdata.Histogram[float64]:
+		for _, dp := range d.DataPoints {
+			if isOverflowSet(dp.Attributes) {
+				return true
+			}
+		}
+	case metricdata.ExponentialHistogram[int64]:
+		for _, dp := range d.DataPoints {
+			if isOverflowSet(dp.Attributes) {
+				return true
+			}
+		}
+	case metricdata.ExponentialHistogram[float64]:
+		for _, dp := range d.DataPoints {
+			if isOverflowSet(dp.Attributes) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isOverflowSet reports whether set is the overflow attribute set
+func isOverflowSet(set attribute.Set) bool {
+	v, ok := set.Value(overflowKey)
+	return ok && v.AsBool()
+}
+
+// familyHasOverflow reports whether a Prometheus metric family has an overflow series
+func familyHasOverflow(f *dto.MetricFamily) bool {
+	for _, m := range f.GetMetric() {
+		for _, l := range m.GetLabel() {
+			if l.GetName() == overflowLabel && l.GetValue() == "true" {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,162 @@
+package observability
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestCardinalityMonitor(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+	monitor := newCardinalityMonitor(&Logger{SugaredLogger: zap.New(core).Sugar()}, 3)
+	now := time.Now()
+	monitor.now = func() time.Time { return now }
+
+	selfReader := sdkmetric.NewManualReader()
+	monitor.setMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(selfReader)))
+
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader), sdkmetric.WithCardinalityLimit(3))
+	ctx := context.Background()
+	requests, _ := mp.Meter("api").Int64Counter("user_requests")
+	for i := 0; i < 10; i++ {
+		requests.Add(ctx, 1, metric.WithAttributes(attribute.String("user_id", fmt.Sprint(i))))
+	}
+	bounded, _ := mp.Meter("api").Int64Counter("route_requests")
+	bounded.Add(ctx, 1, metric.WithAttributes(attribute.String("route", "/orders")))
+
+	collectAndCheck := func() {
+		var rm metricdata.ResourceMetrics
+		if err := reader.Collect(ctx, &rm); err != nil {
+			t.Fatalf("collect failed: %v", err)
+		}
+		monitor.checkResourceMetrics(ctx, &rm)
+	}
+
+	collectAndCheck()
+	collectAndCheck()
+	if entries := logs.FilterMessage("Metric cardinality limit exceeded").All(); len(entries) != 1 || entries[0].ContextMap()["instrument"] != "user_requests" {
+		t.Fatalf("expected a single warning naming user_requests, got %v", entries)
+	}
+
+	// The warning repeats once the rate limit interval has passed
+	now = now.Add(cardinalityWarnInterval)
+	collectAndCheck()
+	if n := logs.FilterMessage("Metric cardinality limit exceeded").Len(); n != 2 {
+		t.Errorf("expected a second warning after the interval, got %d", n)
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := selfReader.Collect(ctx, &rm); err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	sum, ok := rm.ScopeMetrics[0].Metrics[0].Data.(metricdata.Sum[int64])
+	if !ok || len(sum.DataPoints) != 1 || sum.DataPoints[0].Value != 3 {
+		t.Fatalf("expected 3 overflow exports of a single instrument, got %+v", rm.ScopeMetrics[0].Metrics[0].Data)
+	}
+	if name, _ := sum.DataPoints[0].Attributes.Value("metric.name"); name.AsString() != "user_requests" {
+		t.Errorf("expected the overflow counter to name user_requests, got %q", name.AsString())
+	}
+}
+
+func TestNewCardinalityMonitorDisabled(t *testing.T) {
+	if newCardinalityMonitor(NewLogger(&BaseConfig{LogLevel: "info"}), 0) != nil {
+		t.Error("expected no monitor without a cardinality limit")
+	}
+}
+
+func TestCardinalityMonitorMetricName(t *testing.T) {
+	monitor := newCardinalityMonitor(NewLogger(&BaseConfig{LogLevel: "info"}), 3)
+	rename := sdkmetric.NewView(sdkmetric.Instrument{Name: "legacy.jobs"}, sdkmetric.Stream{Name: "jobs"})
+	copyView := sdkmetric.NewView(sdkmetric.Instrument{Name: "legacy.jobs"}, sdkmetric.Stream{Name: "jobs.v2"})
+	buckets := sdkmetric.NewView(sdkmetric.Instrument{Name: "request.duration"}, sdkmetric.Stream{
+		Aggregation: sdkmetric.AggregationExplicitBucketHistogram{Boundaries: []float64{0.1, 1}},
+	})
+	view := monitor.view([]sdkmetric.View{copyView, buckets, rename})
+
+	instruments := []sdkmetric.Instrument{
+		{Name: "user.requests", Kind: sdkmetric.InstrumentKindCounter},
+		{Name: "request.duration", Unit: "s", Kind: sdkmetric.InstrumentKindHistogram},
+		{Name: "queue.depth", Kind: sdkmetric.InstrumentKindObservableUpDownCounter},
+		{Name: "legacy.jobs", Kind: sdkmetric.InstrumentKindCounter},
+	}
+	for _, inst := range instruments {
+		if _, ok := view(inst); ok {
+			t.Fatalf("expected the view not to match %s", inst.Name)
+		}
+	}
+
+	tests := []struct {
+		family string
+		want   string
+	}{
+		{family: "user_requests_total", want: "user.requests"},
+		{family: "request_duration_seconds", want: "request.duration"},
+		{family: "queue_depth", want: "queue.depth"},
+		{family: "jobs_total", want: "jobs"},
+		{family: "jobs_v2_total", want: "jobs.v2"},
+		{family: "legacy_jobs_total", want: "legacy_jobs_total"},
+		{family: "unknown_total", want: "unknown_total"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.family, func(t *testing.T) {
+			if got := monitor.metricName(tt.family); got != tt.want {
+				t.Errorf("metricName(%q) = %q, want %q", tt.family, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewObservability_CardinalityLimit(t *testing.T) {
+	o, err := NewObservability(BaseConfig{
+		ServiceName:        "test-otel-cardinality",
+		Version:            "1.0.0",
+		TracingDisabled:    true,
+		MetricsMode:        "pull",
+		MetricsPath:        "/metrics",
+		MetricsPort:        0,
+		CardinalityLimit:   5,
+		OtelDisableGlobals: true,
+	})
+	if err != nil {
+		t.Fatalf("NewObservability failed: %v", err)
+	}
+	defer func() { _ = o.Shutdown(context.Background()) }()
+
+	requests, _ := o.Meter("api").Int64Counter("user_requests")
+	for i := 0; i < 20; i++ {
+		requests.Add(context.Background(), 1, metric.WithAttributes(attribute.String("user_id", fmt.Sprint(i))))
+	}
+
+	scrape := func() string {
+		resp, err := http.Get("http://" + o.MetricsAddr() + "/metrics")
+		if err != nil {
+			t.Fatalf("failed to scrape metrics: %v", err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		body, _ := io.ReadAll(resp.Body)
+		return string(body)
+	}
+
+	body := scrape()
+	if n := strings.Count(body, "user_requests_total{"); n != 5 {
+		t.Errorf("expected 5 user_requests_total series including overflow, got %d", n)
+	}
+	if !strings.Contains(body, `otel_metric_overflow="true"`) {
+		t.Errorf("expected an overflow series in the scrape, got:\n%s", body)
+	}
+	if body = scrape(); !strings.Contains(body, `metric_cardinality_overflow_total{metric_name="user_requests"`) {
+		t.Errorf("expected the overflow self-metric after the first scrape, got:\n%s", body)
+	}
+}
@@ -2,6 +2,7 @@ package observability
 
 import (
 	"fmt"
+	"net"
 	"os"
 	"reflect"
 	"strings"
@@ -16,23 +17,78 @@ type MetadataSetter interface {
 }
 
 type BaseConfig struct {
-	ServiceName              string  `env:"SERVICE_NAME"`
-	Version                  string
-	BuildTime                string
-	LogLevel                 string  `env:"LOG_LEVEL" env-default:"info"`
-	OtelEndpoint             string  `env:"OTEL_ENDPOINT" env-default:"localhost:4318"`
-	MetricsPort              int     `env:"METRICS_PORT" env-default:"9090"`
-	OtelTracingSampleRate    float64 `env:"OTEL_TRACING_SAMPLE_RATE" env-default:"1.0"`
-	MetricsMode              string  `env:"METRICS_MODE" env-default:"pull"`
-	MetricsPath              string  `env:"METRICS_PATH" env-default:"/metrics"`
-	MetricsPushEndpoint      string  `env:"METRICS_PUSH_ENDPOINT"`
-	MetricsPushInterval      int     `env:"METRICS_PUSH_INTERVAL" env-default:"30"`
-	MetricsProtocol          string  `env:"METRICS_PROTOCOL" env-default:"http"`
-	OtelInsecure             bool    `env:"OTEL_INSECURE" env-default:"false"`
-	MetricsInsecure          bool    `env:"METRICS_INSECURE" env-default:"false"`
+	ServiceName            string `env:"SERVICE_NAME,OTEL_SERVICE_NAME"`
+	Version                string
+	BuildTime              string
+	ServiceInstanceID      string             `env:"SERVICE_INSTANCE_ID"`
+	DeploymentEnvironment  string             `env:"DEPLOYMENT_ENVIRONMENT"`
+	ResourceDetectors      []string           `env:"RESOURCE_DETECTORS" env-default:"host,process,container,k8s,service_instance"`
+	OtelResourceAttributes ResourceAttributes `env:"OTEL_RESOURCE_ATTRIBUTES"`
+	LogLevel               string             `env:"LOG_LEVEL" env-default:"info"`
+	LogsExport             string             `env:"LOGS_EXPORT" env-default:"none"`
+	LogsProtocol           string             `env:"OTEL_LOGS_PROTOCOL" env-default:"http"`
+	LogsEndpoint           string             `env:"OTEL_LOGS_ENDPOINT,OTEL_EXPORTER_OTLP_LOGS_ENDPOINT"`
+	TracingDisabled        bool               `env:"TRACING_DISABLED" env-default:"false"`
+	OtelEndpoint           string             `env:"OTEL_ENDPOINT,OTEL_EXPORTER_OTLP_TRACES_ENDPOINT" env-default:"localhost:4318"`
+	TracesProtocol         string             `env:"OTEL_TRACES_PROTOCOL" env-default:"http"`
+	TracesExport           string             `env:"TRACES_EXPORT" env-default:"otlp"`
+	StdoutExportOutput     string             `env:"STDOUT_EXPORT_OUTPUT" env-default:"stderr"`
+	FileExportDir          string             `env:"FILE_EXPORT_DIR" env-default:"telemetry"`
+	FileExportMaxSizeMB    int                `env:"FILE_EXPORT_MAX_SIZE_MB" env-default:"100"`
+	FileExportMaxFiles     int                `env:"FILE_EXPORT_MAX_FILES" env-default:"5"`
+	MetricsHost            string             `env:"METRICS_HOST" env-default:"0.0.0.0"`
+	MetricsPort            int                `env:"METRICS_PORT" env-default:"9090"`
+	MetricsAuthUsername    string             `env:"METRICS_AUTH_USERNAME"`
+	MetricsAuthPassword    Secret             `env:"METRICS_AUTH_PASSWORD"`
+	MetricsAuthToken       Secret             `env:"METRICS_AUTH_TOKEN"`
+	MetricsServerCert      string             `env:"METRICS_SERVER_CERT"`
+	MetricsServerKey       string             `env:"METRICS_SERVER_KEY"`
+	MetricsServerClientCA  string             `env:"METRICS_SERVER_CLIENT_CA"`
+	OtelTracingSampleRate  float64            `env:"OTEL_TRACING_SAMPLE_RATE" env-default:"1.0"`
+	OtelTracesSampler      string             `env:"OTEL_TRACES_SAMPLER" env-default:"traceidratio"`
+	OtelTracesSamplerArg   string             `env:"OTEL_TRACES_SAMPLER_ARG"`
+	TailSamplingEnabled    bool               `env:"TAIL_SAMPLING_ENABLED" env-default:"false"`
+	TailSamplingLatencyMs  int                `env:"TAIL_SAMPLING_LATENCY_MS" env-default:"1000"`
+	TailSamplingMaxTraces  int                `env:"TAIL_SAMPLING_MAX_TRACES" env-default:"10000"`
+	TailSamplingMaxSpans   int                `env:"TAIL_SAMPLING_MAX_SPANS_PER_TRACE" env-default:"1000"`
+	MetricsMode            string             `env:"METRICS_MODE" env-default:"pull"`
+	RuntimeMetricsDisabled bool               `env:"RUNTIME_METRICS_DISABLED" env-default:"false"`
+	ProcessMetricsDisabled bool               `env:"PROCESS_METRICS_DISABLED" env-default:"false"`
+	MetricsPath            string             `env:"METRICS_PATH" env-default:"/metrics"`
+	MetricsViews           MetricViews        `env:"METRICS_VIEWS"`
+	CardinalityLimit       int                `env:"METRICS_CARDINALITY_LIMIT" env-default:"2000"`
+	ExemplarFilter         string             `env:"METRICS_EXEMPLAR_FILTER,OTEL_METRICS_EXEMPLAR_FILTER" env-default:"trace_based"`
+	HistogramAggregation   string             `env:"METRICS_HISTOGRAM_AGGREGATION" env-default:"explicit"`
+	ExpHistogramMaxSize    int                `env:"METRICS_EXPONENTIAL_HISTOGRAM_MAX_SIZE" env-default:"160"`
+	ExpHistogramMaxScale   int                `env:"METRICS_EXPONENTIAL_HISTOGRAM_MAX_SCALE" env-default:"20"`
+	MetricsPushEndpoint    string             `env:"METRICS_PUSH_ENDPOINT,OTEL_EXPORTER_OTLP_METRICS_ENDPOINT"`
+	MetricsPushInterval    int                `env:"METRICS_PUSH_INTERVAL" env-default:"30"`
+	HealthCheckInterval    int                `env:"HEALTH_CHECK_INTERVAL" env-default:"30"`
+	MetricsProtocol        string             `env:"METRICS_PROTOCOL" env-default:"http"`
+	OtelInsecure           bool               `env:"OTEL_INSECURE" env-default:"false"`
+	MetricsInsecure        bool               `env:"METRICS_INSECURE" env-default:"false"`
+	OtelCACert             string             `env:"OTEL_CA_CERT"`
+	OtelClientCert         string             `env:"OTEL_CLIENT_CERT"`
+	OtelClientKey          string             `env:"OTEL_CLIENT_KEY"`
+	OtelTLSServerName      string             `env:"OTEL_TLS_SERVER_NAME"`
+	MetricsCACert          string             `env:"METRICS_CA_CERT"`
+	MetricsClientCert      string             `env:"METRICS_CLIENT_CERT"`
+	MetricsClientKey       string             `env:"METRICS_CLIENT_KEY"`
+	MetricsTLSServerName   string             `env:"METRICS_TLS_SERVER_NAME"`
+	OtelHeaders            Headers            `env:"OTEL_HEADERS,OTEL_EXPORTER_OTLP_TRACES_HEADERS,OTEL_EXPORTER_OTLP_HEADERS"`
+	OtelCompression        string             `env:"OTEL_COMPRESSION" env-default:"none"`
+	OtelTimeout            int                `env:"OTEL_TIMEOUT" env-default:"10"`
+	MetricsHeaders         Headers            `env:"METRICS_HEADERS,OTEL_EXPORTER_OTLP_METRICS_HEADERS,OTEL_EXPORTER_OTLP_HEADERS"`
+	MetricsCompression     string             `env:"METRICS_COMPRESSION" env-default:"none"`
+	MetricsTimeout         int                `env:"METRICS_TIMEOUT" env-default:"10"`
+	PprofEnabled           bool               `env:"DEBUG_PPROF_ENABLED" env-default:"false"`
+	ExpvarEnabled          bool               `env:"DEBUG_EXPVAR_ENABLED" env-default:"false"`
+	BuildInfoEnabled       bool               `env:"DEBUG_BUILD_INFO_ENABLED" env-default:"false"`
+	DebugAddr              string             `env:"DEBUG_ADDR"`
+	DebugAuthToken         Secret             `env:"DEBUG_AUTH_TOKEN"`
+	OtelDisableGlobals     bool               `env:"OTEL_DISABLE_GLOBALS" env-default:"false"`
 }
 
-
 func (b *BaseConfig) SetMetadata(s, v, t string) {
 	if s != "" && strings.TrimSpace(b.ServiceName) == "" {
 		b.ServiceName = s
@@ -59,6 +115,27 @@ func (b *BaseConfig) IsHybrid() bool {
 	return mode == "hybrid"
 }
 
+// IsMetricsDisabled returns true if MetricsMode is "none"
+func (b *BaseConfig) IsMetricsDisabled() bool {
+	mode := strings.ToLower(strings.TrimSpace(b.MetricsMode))
+	return mode == "none"
+}
+
+// IsTracingEnabled returns true unless TracingDisabled is set
+func (b *BaseConfig) IsTracingEnabled() bool {
+	return !b.TracingDisabled
+}
+
+// IsRuntimeMetricsEnabled returns true unless RuntimeMetricsDisabled is set
+func (b *BaseConfig) IsRuntimeMetricsEnabled() bool {
+	return !b.RuntimeMetricsDisabled
+}
+
+// IsProcessMetricsEnabled returns true unless ProcessMetricsDisabled is set
+func (b *BaseConfig) IsProcessMetricsEnabled() bool {
+	return !b.ProcessMetricsDisabled
+}
+
 func LoadCfg(cfg any) error {
 	// 1. Priority: .env > Environment Variables
 	if _, err := os.Stat(".env"); err == nil {
@@ -71,12 +148,15 @@ func LoadCfg(cfg any) error {
 		}
 	}
 
-	// 2. Inject LDFlags if applicable
+	// 2. Fall back to the spec-defined OTEL_EXPORTER_OTLP_ENDPOINT for unset endpoints
+	applyOtlpEndpointFallback(cfg)
+
+	// 3. Inject LDFlags if applicable
 	if ms, ok := cfg.(MetadataSetter); ok {
 		ms.SetMetadata(GetServiceName(), GetVersion(), GetBuildTime())
 	}
 
-	// 3. Post-processing & Validation
+	// 4. Post-processing & Validation
 	return finalizeAndValidate(cfg)
 }
 
@@ -111,14 +191,28 @@ func finalizeAndValidate(cfg any) error {
 		}
 	}
 
+	// Logic for ResourceDetectors validation
+	rdField := v.FieldByName("ResourceDetectors")
+	if rdField.IsValid() && rdField.Kind() == reflect.Slice {
+		for i := 0; i < rdField.Len(); i++ {
+			d := strings.ToLower(strings.TrimSpace(rdField.Index(i).String()))
+			if d == "" || d == "none" {
+				continue
+			}
+			if !validDetectors[d] {
+				return fmt.Errorf("invalid RESOURCE_DETECTORS entry: %s (must be one of host, process, container, k8s, service_instance)", d)
+			}
+		}
+	}
+
 	// Logic for MetricsMode validation
 	mmField := v.FieldByName("MetricsMode")
 	if mmField.IsValid() {
 		mm := strings.ToLower(strings.TrimSpace(mmField.String()))
 		switch mm {
-		case "pull", "push", "hybrid":
+		case "pull", "push", "hybrid", "stdout", "file", "none":
 		default:
-			return fmt.Errorf("invalid METRICS_MODE: %s (must be 'pull', 'push', or 'hybrid')", mm)
+			return fmt.Errorf("invalid METRICS_MODE: %s (must be 'pull', 'push', 'hybrid', 'stdout', 'file', or 'none')", mm)
 		}
 
 		// If push mode, MetricsPushEndpoint is required
@@ -130,6 +224,45 @@ func finalizeAndValidate(cfg any) error {
 		}
 	}
 
+	// Logic for MetricsViews validation
+	mvField := v.FieldByName("MetricsViews")
+	if mvField.IsValid() && mvField.CanInterface() {
+		if views, ok := mvField.Interface().(MetricViews); ok {
+			if err := views.Validate(); err != nil {
+				return fmt.Errorf("invalid METRICS_VIEWS: %w", err)
+			}
+		}
+	}
+
+	// Logic for histogram aggregation validation
+	haField := v.FieldByName("HistogramAggregation")
+	if haField.IsValid() {
+		ha := strings.ToLower(strings.TrimSpace(haField.String()))
+		switch ha {
+		case "", HistogramAggregationExplicit, HistogramAggregationExponential:
+		default:
+			return fmt.Errorf("invalid METRICS_HISTOGRAM_AGGREGATION: %s (must be 'explicit' or 'exponential')", ha)
+		}
+	}
+	if f := v.FieldByName("ExpHistogramMaxSize"); f.IsValid() && f.Kind() == reflect.Int && f.Int() < 0 {
+		return fmt.Errorf("invalid METRICS_EXPONENTIAL_HISTOGRAM_MAX_SIZE: %d (must be >= 0)", f.Int())
+	}
+	if f := v.FieldByName("ExpHistogramMaxScale"); f.IsValid() && f.Kind() == reflect.Int {
+		if scale := f.Int(); scale < expHistogramMinScale || scale > expHistogramMaxScale {
+			return fmt.Errorf("invalid METRICS_EXPONENTIAL_HISTOGRAM_MAX_SCALE: %d (must be between %d and %d)", scale, expHistogramMinScale, expHistogramMaxScale)
+		}
+	}
+
+	// Logic for ExemplarFilter validation
+	efField := v.FieldByName("ExemplarFilter")
+	if efField.IsValid() {
+		switch ef := exemplarFilterName(efField.String()); ef {
+		case ExemplarFilterAlways, ExemplarFilterTraceBased, ExemplarFilterOff:
+		default:
+			return fmt.Errorf("invalid METRICS_EXEMPLAR_FILTER: %s (must be 'always', 'trace_based', or 'off')", ef)
+		}
+	}
+
 	// Logic for MetricsPort validation
 	portField := v.FieldByName("MetricsPort")
 	if portField.IsValid() {
@@ -137,8 +270,9 @@ func finalizeAndValidate(cfg any) error {
 		switch portField.Kind() {
 		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 			port := int(portField.Int())
-			if port < 1 || port > 65535 {
-				return fmt.Errorf("invalid METRICS_PORT: %d (must be between 1 and 65535)", port)
+			// 0 lets the OS pick a free port (see Observability.MetricsAddr)
+			if port < 0 || port > 65535 {
+				return fmt.Errorf("invalid METRICS_PORT: %d (must be between 0 and 65535)", port)
 			}
 		}
 	}
@@ -154,5 +288,275 @@ func finalizeAndValidate(cfg any) error {
 		}
 	}
 
+	// Logic for TracesProtocol validation
+	tpField := v.FieldByName("TracesProtocol")
+	if tpField.IsValid() {
+		tp := strings.ToLower(strings.TrimSpace(tpField.String()))
+		switch tp {
+		case "http", "grpc":
+		default:
+			return fmt.Errorf("invalid OTEL_TRACES_PROTOCOL: %s (must be 'http' or 'grpc')", tp)
+		}
+	}
+
+	// Logic for TracesExport validation
+	teExportField := v.FieldByName("TracesExport")
+	if teExportField.IsValid() {
+		te := strings.ToLower(strings.TrimSpace(teExportField.String()))
+		switch te {
+		case "", TracesExportOTLP, TracesExportStdout, TracesExportFile:
+		default:
+			return fmt.Errorf("invalid TRACES_EXPORT: %s (must be 'otlp', 'stdout', or 'file')", te)
+		}
+	}
+
+	// Logic for StdoutExportOutput validation
+	soField := v.FieldByName("StdoutExportOutput")
+	if soField.IsValid() {
+		so := strings.ToLower(strings.TrimSpace(soField.String()))
+		switch so {
+		case "", StdoutOutputStderr, StdoutOutputLogger:
+		default:
+			return fmt.Errorf("invalid STDOUT_EXPORT_OUTPUT: %s (must be 'stderr' or 'logger')", so)
+		}
+	}
+
+	// Logic for endpoint URL validation
+	for _, ep := range []struct{ env, field string }{
+		{"OTEL_ENDPOINT", "OtelEndpoint"},
+		{"METRICS_PUSH_ENDPOINT", "MetricsPushEndpoint"},
+		{"OTEL_LOGS_ENDPOINT", "LogsEndpoint"},
+	} {
+		if err := validateEndpoint(ep.env, stringField(v, ep.field)); err != nil {
+			return err
+		}
+	}
+
+	// Logic for LogsExport validation
+	leField := v.FieldByName("LogsExport")
+	if leField.IsValid() {
+		le := strings.ToLower(strings.TrimSpace(leField.String()))
+		switch le {
+		case "", LogsExportNone, LogsExportOTLP:
+		default:
+			return fmt.Errorf("invalid LOGS_EXPORT: %s (must be 'none' or 'otlp')", le)
+		}
+	}
+
+	// Logic for LogsProtocol validation
+	lpField := v.FieldByName("LogsProtocol")
+	if lpField.IsValid() {
+		lp := strings.ToLower(strings.TrimSpace(lpField.String()))
+		switch lp {
+		case "", "http", "grpc":
+		default:
+			return fmt.Errorf("invalid OTEL_LOGS_PROTOCOL: %s (must be 'http' or 'grpc')", lp)
+		}
+	}
+
+	// Logic for sampler validation
+	rateField := v.FieldByName("OtelTracingSampleRate")
+	if rateField.IsValid() && rateField.Kind() == reflect.Float64 {
+		rate := rateField.Float()
+		if rate < 0 || rate > 1 {
+			return fmt.Errorf("invalid OTEL_TRACING_SAMPLE_RATE: %v (must be between 0 and 1)", rate)
+		}
+
+		samplerField := v.FieldByName("OtelTracesSampler")
+		if samplerField.IsValid() {
+			if _, err := parseSamplerArg(samplerField.String(), stringField(v, "OtelTracesSamplerArg"), rate); err != nil {
+				return err
+			}
+
+			// Tail sampling applies the head sampler's ratio to completed traces
+			tsField := v.FieldByName("TailSamplingEnabled")
+			if tsField.IsValid() && tsField.Kind() == reflect.Bool && tsField.Bool() {
+				if _, err := tailSamplingRatio(BaseConfig{
+					OtelTracesSampler:     samplerField.String(),
+					OtelTracesSamplerArg:  stringField(v, "OtelTracesSamplerArg"),
+					OtelTracingSampleRate: rate,
+				}); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	// Logic for tail sampling, file export, cardinality limit and health check interval validation
+	for _, c := range []struct{ field, env string }{
+		{"TailSamplingLatencyMs", "TAIL_SAMPLING_LATENCY_MS"},
+		{"TailSamplingMaxTraces", "TAIL_SAMPLING_MAX_TRACES"},
+		{"TailSamplingMaxSpans", "TAIL_SAMPLING_MAX_SPANS_PER_TRACE"},
+		{"FileExportMaxSizeMB", "FILE_EXPORT_MAX_SIZE_MB"},
+		{"FileExportMaxFiles", "FILE_EXPORT_MAX_FILES"},
+		{"CardinalityLimit", "METRICS_CARDINALITY_LIMIT"},
+		{"HealthCheckInterval", "HEALTH_CHECK_INTERVAL"},
+	} {
+		lf := v.FieldByName(c.field)
+		if lf.IsValid() && lf.Kind() == reflect.Int && lf.Int() < 0 {
+			return fmt.Errorf("invalid %s: %d (must be >= 0)", c.env, lf.Int())
+		}
+	}
+
+	// Logic for metrics server auth and TLS validation
+	if (strings.TrimSpace(stringField(v, "MetricsAuthUsername")) == "") != (stringField(v, "MetricsAuthPassword") == "") {
+		return fmt.Errorf("METRICS_AUTH_USERNAME and METRICS_AUTH_PASSWORD must be set together")
+	}
+	if err := validateServerTLSFields(v); err != nil {
+		return err
+	}
+
+	// Logic for debug endpoints validation
+	debugEnabled := false
+	for _, name := range []string{"PprofEnabled", "ExpvarEnabled", "BuildInfoEnabled"} {
+		if f := v.FieldByName(name); f.IsValid() && f.Kind() == reflect.Bool && f.Bool() {
+			debugEnabled = true
+		}
+	}
+	if da := strings.TrimSpace(stringField(v, "DebugAddr")); da != "" {
+		if _, _, err := net.SplitHostPort(da); err != nil {
+			return fmt.Errorf("invalid DEBUG_ADDR: %s (must be host:port)", da)
+		}
+	} else if debugEnabled {
+		mm := strings.ToLower(strings.TrimSpace(stringField(v, "MetricsMode")))
+		if mm != "pull" && mm != "hybrid" {
+			return fmt.Errorf("DEBUG_ADDR is required for debug endpoints without a pull/hybrid metrics server")
+		}
+		// The metrics server listens on every interface by default: never expose the endpoints there openly
+		authSet := false
+		for _, name := range []string{"DebugAuthToken", "MetricsAuthUsername", "MetricsAuthToken", "MetricsServerClientCA"} {
+			if strings.TrimSpace(stringField(v, name)) != "" {
+				authSet = true
+			}
+		}
+		if !authSet {
+			return fmt.Errorf("debug endpoints on the metrics server require DEBUG_AUTH_TOKEN, metrics server auth or DEBUG_ADDR")
+		}
+	}
+
+	// Logic for exporter compression validation
+	for _, c := range []struct{ field, env string }{
+		{"OtelCompression", "OTEL_COMPRESSION"},
+		{"MetricsCompression", "METRICS_COMPRESSION"},
+	} {
+		cf := v.FieldByName(c.field)
+		if !cf.IsValid() {
+			continue
+		}
+		comp := strings.ToLower(strings.TrimSpace(cf.String()))
+		switch comp {
+		case "", "none", "gzip":
+		default:
+			return fmt.Errorf("invalid %s: %s (must be 'none' or 'gzip')", c.env, comp)
+		}
+	}
+
+	// Logic for exporter timeout validation
+	for _, c := range []struct{ field, env string }{
+		{"OtelTimeout", "OTEL_TIMEOUT"},
+		{"MetricsTimeout", "METRICS_TIMEOUT"},
+	} {
+		tf := v.FieldByName(c.field)
+		if !tf.IsValid() {
+			continue
+		}
+		switch tf.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if tf.Int() < 0 {
+				return fmt.Errorf("invalid %s: %d (must be >= 0 seconds)", c.env, tf.Int())
+			}
+		}
+	}
+
+	// Logic for TLS files validation (trace and metrics exporters)
+	if err := validateTLSFields(v, "OTEL", "Otel"); err != nil {
+		return err
+	}
+	if err := validateTLSFields(v, "METRICS", "Metrics"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// stringField returns the string value of a struct field, or "" if it does not exist
+func stringField(v reflect.Value, name string) string {
+	f := v.FieldByName(name)
+	if !f.IsValid() || f.Kind() != reflect.String {
+		return ""
+	}
+	return f.String()
+}
+
+// validateTLSFields checks that configured CA, certificate and key files are readable and valid
+func validateTLSFields(v reflect.Value, envPrefix, fieldPrefix string) error {
+	files := tlsFiles{
+		CAFile:     stringField(v, fieldPrefix+"CACert"),
+		CertFile:   stringField(v, fieldPrefix+"ClientCert"),
+		KeyFile:    stringField(v, fieldPrefix+"ClientKey"),
+		ServerName: stringField(v, fieldPrefix+"TLSServerName"),
+	}
+
+	checks := []struct {
+		env  string
+		path string
+	}{
+		{envPrefix + "_CA_CERT", files.CAFile},
+		{envPrefix + "_CLIENT_CERT", files.CertFile},
+		{envPrefix + "_CLIENT_KEY", files.KeyFile},
+	}
+	for _, c := range checks {
+		path := strings.TrimSpace(c.path)
+		if path == "" {
+			continue
+		}
+		if _, err := os.ReadFile(path); err != nil {
+			return fmt.Errorf("invalid %s: cannot read %s: %w", c.env, path, err)
+		}
+	}
+
+	if (strings.TrimSpace(files.CertFile) == "") != (strings.TrimSpace(files.KeyFile) == "") {
+		return fmt.Errorf("%s_CLIENT_CERT and %s_CLIENT_KEY must be set together", envPrefix, envPrefix)
+	}
+
+	if _, err := newTLSConfig(files); err != nil {
+		return fmt.Errorf("invalid %s TLS configuration: %w", envPrefix, err)
+	}
+	return nil
+}
+
+// validateServerTLSFields checks the metrics server certificate, key and client CA files
+func validateServerTLSFields(v reflect.Value) error {
+	files := tlsFiles{
+		CAFile:   stringField(v, "MetricsServerClientCA"),
+		CertFile: stringField(v, "MetricsServerCert"),
+		KeyFile:  stringField(v, "MetricsServerKey"),
+	}
+
+	checks := []struct {
+		env  string
+		path string
+	}{
+		{"METRICS_SERVER_CERT", files.CertFile},
+		{"METRICS_SERVER_KEY", files.KeyFile},
+		{"METRICS_SERVER_CLIENT_CA", files.CAFile},
+	}
+	for _, c := range checks {
+		path := strings.TrimSpace(c.path)
+		if path == "" {
+			continue
+		}
+		if _, err := os.ReadFile(path); err != nil {
+			return fmt.Errorf("invalid %s: cannot read %s: %w", c.env, path, err)
+		}
+	}
+
+	if files.isSet() && (strings.TrimSpace(files.CertFile) == "" || strings.TrimSpace(files.KeyFile) == "") {
+		return fmt.Errorf("METRICS_SERVER_CERT and METRICS_SERVER_KEY are required for metrics server TLS")
+	}
+
+	if _, err := newServerTLSConfig(files); err != nil {
+		return fmt.Errorf("invalid METRICS_SERVER TLS configuration: %w", err)
+	}
 	return nil
 }
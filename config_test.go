@@ -185,12 +185,12 @@ func TestLoadCfg(t *testing.T) {
 		// Ensure a valid metrics mode for these checks
 		_ = os.Setenv("METRICS_MODE", "pull")
 
-		// Invalid: 0
-		_ = os.Setenv("METRICS_PORT", "0")
+		// Invalid: negative
+		_ = os.Setenv("METRICS_PORT", "-1")
 		var cfg BaseConfig
 		err := LoadCfg(&cfg)
 		if err == nil {
-			t.Error("Expected LoadCfg to fail for METRICS_PORT=0")
+			t.Error("Expected LoadCfg to fail for METRICS_PORT=-1")
 		}
 
 		// Invalid: too large
@@ -200,11 +200,11 @@ func TestLoadCfg(t *testing.T) {
 			t.Error("Expected LoadCfg to fail for METRICS_PORT=70000")
 		}
 
-		// Valid: lower bound
-		_ = os.Setenv("METRICS_PORT", "1")
+		// Valid: lower bound (0 picks a free port)
+		_ = os.Setenv("METRICS_PORT", "0")
 		err = LoadCfg(&cfg)
 		if err != nil {
-			t.Fatalf("LoadCfg failed for METRICS_PORT=1: %v", err)
+			t.Fatalf("LoadCfg failed for METRICS_PORT=0: %v", err)
 		}
 
 		// Valid: upper bound
@@ -283,6 +283,107 @@ func TestLoadCfg(t *testing.T) {
 			t.Errorf("Expected default MetricsProtocol 'http', got '%s'", cfg.MetricsProtocol)
 		}
 	})
+
+	t.Run("Traces Protocol - gRPC", func(t *testing.T) {
+		_ = os.Unsetenv("LOG_LEVEL")
+		_ = os.Setenv("SERVICE_NAME", "grpc-traces-service")
+		_ = os.Setenv("METRICS_MODE", "pull")
+		_ = os.Setenv("OTEL_TRACES_PROTOCOL", "grpc")
+		defer func() { _ = os.Unsetenv("OTEL_TRACES_PROTOCOL") }()
+
+		var cfg BaseConfig
+		err := LoadCfg(&cfg)
+		if err != nil {
+			t.Fatalf("LoadCfg failed: %v", err)
+		}
+
+		if cfg.TracesProtocol != "grpc" {
+			t.Errorf("Expected TracesProtocol 'grpc', got '%s'", cfg.TracesProtocol)
+		}
+	})
+
+	t.Run("Default Traces Protocol", func(t *testing.T) {
+		_ = os.Unsetenv("LOG_LEVEL")
+		_ = os.Setenv("SERVICE_NAME", "default-traces-service")
+		_ = os.Setenv("METRICS_MODE", "pull")
+		_ = os.Unsetenv("OTEL_TRACES_PROTOCOL")
+
+		var cfg BaseConfig
+		err := LoadCfg(&cfg)
+		if err != nil {
+			t.Fatalf("LoadCfg failed: %v", err)
+		}
+
+		if cfg.TracesProtocol != "http" {
+			t.Errorf("Expected default TracesProtocol 'http', got '%s'", cfg.TracesProtocol)
+		}
+	})
+
+	t.Run("Invalid Traces Protocol", func(t *testing.T) {
+		_ = os.Unsetenv("LOG_LEVEL")
+		_ = os.Setenv("SERVICE_NAME", "invalid-traces-service")
+		_ = os.Setenv("METRICS_MODE", "pull")
+		_ = os.Setenv("OTEL_TRACES_PROTOCOL", "thrift")
+		defer func() { _ = os.Unsetenv("OTEL_TRACES_PROTOCOL") }()
+
+		var cfg BaseConfig
+		err := LoadCfg(&cfg)
+		if err == nil {
+			t.Error("Expected LoadCfg to fail due to invalid OTEL_TRACES_PROTOCOL")
+		}
+	})
+
+	t.Run("Disabled Metrics And Tracing", func(t *testing.T) {
+		_ = os.Unsetenv("LOG_LEVEL")
+		_ = os.Setenv("SERVICE_NAME", "disabled-telemetry-service")
+		_ = os.Setenv("METRICS_MODE", "none")
+		_ = os.Setenv("TRACING_DISABLED", "true")
+		defer func() {
+			_ = os.Setenv("METRICS_MODE", "pull")
+			_ = os.Unsetenv("TRACING_DISABLED")
+		}()
+
+		var cfg BaseConfig
+		err := LoadCfg(&cfg)
+		if err != nil {
+			t.Fatalf("LoadCfg failed: %v", err)
+		}
+
+		if !cfg.IsMetricsDisabled() || cfg.IsPull() || cfg.IsPush() {
+			t.Error("Expected only IsMetricsDisabled() to return true for 'none' mode")
+		}
+		if cfg.IsTracingEnabled() {
+			t.Error("Expected IsTracingEnabled() to return false for TRACING_DISABLED=true")
+		}
+	})
+
+	t.Run("Invalid Tracing Disabled", func(t *testing.T) {
+		_ = os.Unsetenv("LOG_LEVEL")
+		_ = os.Setenv("SERVICE_NAME", "invalid-tracing-service")
+		_ = os.Setenv("METRICS_MODE", "pull")
+		_ = os.Setenv("TRACING_DISABLED", "sometimes")
+		defer func() { _ = os.Unsetenv("TRACING_DISABLED") }()
+
+		var cfg BaseConfig
+		err := LoadCfg(&cfg)
+		if err == nil {
+			t.Error("Expected LoadCfg to fail due to invalid TRACING_DISABLED")
+		}
+	})
+
+	t.Run("Invalid Runtime Metrics Disabled", func(t *testing.T) {
+		_ = os.Unsetenv("LOG_LEVEL")
+		_ = os.Setenv("SERVICE_NAME", "invalid-runtime-service")
+		_ = os.Setenv("METRICS_MODE", "pull")
+		_ = os.Setenv("RUNTIME_METRICS_DISABLED", "maybe")
+		defer func() { _ = os.Unsetenv("RUNTIME_METRICS_DISABLED") }()
+
+		var cfg BaseConfig
+		err := LoadCfg(&cfg)
+		if err == nil {
+			t.Error("Expected LoadCfg to fail due to invalid RUNTIME_METRICS_DISABLED")
+		}
+	})
 }
 
 func TestSetMetadataAndFinalizeNonStruct(t *testing.T) {
@@ -0,0 +1,169 @@
+package observability
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+)
+
+// Paths of the debug endpoints
+const (
+	PprofPath     = "/debug/pprof/"
+	ExpvarPath    = "/debug/vars"
+	BuildInfoPath = "/debug/buildinfo"
+)
+
+// debugModule is the import path of the package linking the pprof and expvar endpoints
+const debugModule = "github.com/ecoma-io/go-observability/debug"
+
+// The pprof and expvar handlers are registered by the debug subpackage: net/http/pprof and expvar
+// mount themselves on http.DefaultServeMux when linked, so this package must not import them.
+var (
+	debugMu       sync.RWMutex
+	pprofHandlers map[string]http.Handler
+	expvarHandler http.Handler
+)
+
+// RegisterDebugHandlers provides the pprof handlers by path and the expvar handler served when
+// DEBUG_PPROF_ENABLED and DEBUG_EXPVAR_ENABLED are set. It is called by the debug subpackage.
+func RegisterDebugHandlers(pprof map[string]http.Handler, expvar http.Handler) {
+	debugMu.Lock()
+	defer debugMu.Unlock()
+	pprofHandlers = pprof
+	expvarHandler = expvar
+}
+
+// buildInfo is the JSON body of the build-info endpoint
+type buildInfo struct {
+	ServiceName string            `json:"service_name"`
+	Version     string            `json:"version"`
+	BuildTime   string            `json:"build_time"`
+	GoVersion   string            `json:"go_version"`
+	Path        string            `json:"path,omitempty"`
+	Module      string            `json:"module,omitempty"`
+	Settings    map[string]string `json:"settings,omitempty"`
+}
+
+// isDebugEnabled reports whether any debug endpoint is enabled
+func (b *BaseConfig) isDebugEnabled() bool {
+	return b.PprofEnabled || b.ExpvarEnabled || b.BuildInfoEnabled
+}
+
+// debugHandlers returns the enabled debug endpoints by path, each requiring DebugAuthToken when
+// set. It fails if pprof or expvar is enabled but the debug subpackage is not linked.
+func debugHandlers(cfg BaseConfig) (map[string]http.Handler, error) {
+	debugMu.RLock()
+	defer debugMu.RUnlock()
+
+	handlers := map[string]http.Handler{}
+	if cfg.PprofEnabled {
+		if pprofHandlers == nil {
+			return nil, fmt.Errorf("DEBUG_PPROF_ENABLED requires importing %s", debugModule)
+		}
+		for path, h := range pprofHandlers {
+			handlers[path] = h
+		}
+	}
+	if cfg.ExpvarEnabled {
+		if expvarHandler == nil {
+			return nil, fmt.Errorf("DEBUG_EXPVAR_ENABLED requires importing %s", debugModule)
+		}
+		handlers[ExpvarPath] = expvarHandler
+	}
+	if cfg.BuildInfoEnabled {
+		handlers[BuildInfoPath] = buildInfoHandler(cfg)
+	}
+
+	// On the metrics server, endpoints without their own token are at least as protected as metrics
+	creds := authCredentials{Token: string(cfg.DebugAuthToken)}
+	if !creds.isSet() && strings.TrimSpace(cfg.DebugAddr) == "" {
+		creds = cfg.metricsAuth()
+	}
+	for path, h := range handlers {
+		handlers[path] = requireAuth("debug", creds, h)
+	}
+	return handlers, nil
+}
+
+// buildInfoHandler serves the service metadata and the module and VCS information embedded by
+// the Go toolchain
+func buildInfoHandler(cfg BaseConfig) http.Handler {
+	info := buildInfo{
+		ServiceName: cfg.ServiceName,
+		Version:     cfg.Version,
+		BuildTime:   cfg.BuildTime,
+		GoVersion:   runtime.Version(),
+	}
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		info.Path = bi.Path
+		info.Module = bi.Main.Path
+		if bi.Main.Version != "" {
+			info.Module += "@" + bi.Main.Version
+		}
+		for _, s := range bi.Settings {
+			// vcs.revision, vcs.time, vcs.modified, GOOS, GOARCH, CGO_ENABLED...
+			if strings.HasPrefix(s.Key, "vcs.") || strings.ToUpper(s.Key) == s.Key {
+				if info.Settings == nil {
+					info.Settings = map[string]string{}
+				}
+				info.Settings[s.Key] = s.Value
+			}
+		}
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(info)
+	})
+}
+
+// startDebugServer serves the debug endpoints on DebugAddr, apart from the metrics server
+func (o *Observability) startDebugServer(cfg BaseConfig) error {
+	handlers, err := debugHandlers(cfg)
+	if err != nil {
+		return err
+	}
+	mux := http.NewServeMux()
+	for path, h := range handlers {
+		mux.Handle(path, h)
+	}
+	// An address without host, e.g. ":6060", listens on loopback only
+	addr := strings.TrimSpace(cfg.DebugAddr)
+	if host, port, err := net.SplitHostPort(addr); err == nil && host == "" {
+		addr = net.JoinHostPort("127.0.0.1", port)
+	}
+	server := &http.Server{
+		Addr:    addr,
+		Handler: mux,
+	}
+
+	ln, err := net.Listen("tcp", server.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to bind debug server addr %s: %w", server.Addr, err)
+	}
+
+	go func() {
+		if err := server.Serve(ln); err != nil && err != http.ErrServerClosed {
+			o.Logger.Error("Debug server error", "error", err)
+		}
+	}()
+
+	o.debugServer = server
+	o.debugAddr = ln.Addr()
+	o.debugListener = ln
+	return nil
+}
+
+// DebugAddr returns the address the dedicated debug server is bound to, or "" if none runs.
+// Without DEBUG_ADDR the debug endpoints are served by the metrics server instead.
+func (o *Observability) DebugAddr() string {
+	if o.debugAddr == nil {
+		return ""
+	}
+	return o.debugAddr.String()
+}
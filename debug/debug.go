@@ -0,0 +1,27 @@
+// Package debug links the pprof and expvar endpoints into go-observability. Import it for its side
+// effect in services that set DEBUG_PPROF_ENABLED or DEBUG_EXPVAR_ENABLED:
+//
+//	import _ "github.com/ecoma-io/go-observability/debug"
+//
+// net/http/pprof registers its handlers on http.DefaultServeMux when linked, which is why the main
+// package leaves it out. Never serve DefaultServeMux on a public listener in a service importing
+// this package.
+package debug
+
+import (
+	"expvar"
+	"net/http"
+	"net/http/pprof"
+
+	observability "github.com/ecoma-io/go-observability"
+)
+
+func init() {
+	observability.RegisterDebugHandlers(map[string]http.Handler{
+		observability.PprofPath:             http.HandlerFunc(pprof.Index),
+		observability.PprofPath + "cmdline": http.HandlerFunc(pprof.Cmdline),
+		observability.PprofPath + "profile": http.HandlerFunc(pprof.Profile),
+		observability.PprofPath + "symbol":  http.HandlerFunc(pprof.Symbol),
+		observability.PprofPath + "trace":   http.HandlerFunc(pprof.Trace),
+	}, expvar.Handler())
+}
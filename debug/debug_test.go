@@ -0,0 +1,67 @@
+package debug
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	observability "github.com/ecoma-io/go-observability"
+)
+
+func TestDebugEndpoints(t *testing.T) {
+	o, err := observability.NewObservability(observability.BaseConfig{
+		ServiceName:        "test-otel-debug",
+		Version:            "1.0.0",
+		TracingDisabled:    true,
+		MetricsMode:        "pull",
+		MetricsPath:        "/metrics",
+		MetricsPort:        0,
+		PprofEnabled:       true,
+		ExpvarEnabled:      true,
+		DebugAuthToken:     "s3cret",
+		OtelDisableGlobals: true,
+	})
+	if err != nil {
+		t.Fatalf("NewObservability failed: %v", err)
+	}
+	defer func() { _ = o.Shutdown(context.Background()) }()
+
+	get := func(t *testing.T, path, token string) (int, string) {
+		t.Helper()
+		req, _ := http.NewRequest(http.MethodGet, "http://"+o.MetricsAddr()+path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("failed to get %s: %v", path, err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(body)
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		token    string
+		wantCode int
+		wantBody string
+	}{
+		{name: "Pprof Without Token", path: observability.PprofPath + "goroutine?debug=1", wantCode: http.StatusUnauthorized},
+		{name: "Pprof", path: observability.PprofPath + "goroutine?debug=1", token: "s3cret", wantCode: http.StatusOK, wantBody: "goroutine profile"},
+		{name: "Expvar", path: observability.ExpvarPath, token: "s3cret", wantCode: http.StatusOK, wantBody: `"memstats"`},
+		{name: "Build Info Disabled", path: observability.BuildInfoPath, token: "s3cret", wantCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, body := get(t, tt.path, tt.token)
+			if code != tt.wantCode || !strings.Contains(body, tt.wantBody) {
+				t.Errorf("expected %d with %q, got %d %s", tt.wantCode, tt.wantBody, code, body)
+			}
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package observability
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestDebugHandlers(t *testing.T) {
+	stub := http.NotFoundHandler()
+	defer RegisterDebugHandlers(nil, nil)
+
+	tests := []struct {
+		name    string
+		linked  bool
+		cfg     BaseConfig
+		want    []string
+		wantErr bool
+	}{
+		{name: "Disabled", cfg: BaseConfig{}, want: nil},
+		{name: "Build Info", cfg: BaseConfig{BuildInfoEnabled: true}, want: []string{BuildInfoPath}},
+		{name: "Pprof Not Linked", cfg: BaseConfig{PprofEnabled: true}, wantErr: true},
+		{name: "Expvar Not Linked", cfg: BaseConfig{ExpvarEnabled: true}, wantErr: true},
+		{name: "Pprof And Expvar Linked", linked: true, cfg: BaseConfig{PprofEnabled: true, ExpvarEnabled: true}, want: []string{PprofPath, PprofPath + "profile", ExpvarPath}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			RegisterDebugHandlers(nil, nil)
+			if tt.linked {
+				RegisterDebugHandlers(map[string]http.Handler{PprofPath: stub, PprofPath + "profile": stub}, stub)
+			}
+			handlers, err := debugHandlers(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("debugHandlers() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, path := range tt.want {
+				if handlers[path] == nil {
+					t.Errorf("expected a handler for %s", path)
+				}
+			}
+			if tt.want == nil && len(handlers) != 0 {
+				t.Errorf("expected no handlers, got %d", len(handlers))
+			}
+		})
+	}
+}
+
+func TestDefaultServeMuxWithoutPprof(t *testing.T) {
+	for _, path := range []string{PprofPath, PprofPath + "heap"} {
+		rec := httptest.NewRecorder()
+		http.DefaultServeMux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("expected %s not to be served by http.DefaultServeMux, got %d", path, rec.Code)
+		}
+	}
+}
+
+func TestBuildInfoHandler(t *testing.T) {
+	rec := httptest.NewRecorder()
+	buildInfoHandler(BaseConfig{ServiceName: "orders", Version: "1.2.3", BuildTime: "2024-01-01"}).
+		ServeHTTP(rec, httptest.NewRequest(http.MethodGet, BuildInfoPath, nil))
+
+	var info buildInfo
+	if err := json.Unmarshal(rec.Body.Bytes(), &info); err != nil {
+		t.Fatalf("invalid JSON response %q: %v", rec.Body.String(), err)
+	}
+	if info.ServiceName != "orders" || info.Version != "1.2.3" || info.BuildTime != "2024-01-01" || !strings.HasPrefix(info.GoVersion, "go") {
+		t.Errorf("unexpected build info %+v", info)
+	}
+}
+
+func TestNewObservability_DebugEndpoints(t *testing.T) {
+	get := func(t *testing.T, url, token string) (int, string) {
+		t.Helper()
+		req, _ := http.NewRequest(http.MethodGet, url, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("failed to get %s: %v", url, err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(body)
+	}
+
+	t.Run("Metrics Server", func(t *testing.T) {
+		o, err := NewObservability(BaseConfig{
+			ServiceName:        "test-otel-debug",
+			Version:            "1.0.0",
+			TracingDisabled:    true,
+			MetricsMode:        "pull",
+			MetricsPath:        "/metrics",
+			MetricsPort:        0,
+			BuildInfoEnabled:   true,
+			DebugAuthToken:     "s3cret",
+			OtelDisableGlobals: true,
+		})
+		if err != nil {
+			t.Fatalf("NewObservability failed: %v", err)
+		}
+		defer func() { _ = o.Shutdown(context.Background()) }()
+
+		base := "http://" + o.MetricsAddr()
+		if code, _ := get(t, base+BuildInfoPath, ""); code != http.StatusUnauthorized {
+			t.Errorf("expected build info to require the token, got %d", code)
+		}
+		if code, body := get(t, base+BuildInfoPath, "s3cret"); code != http.StatusOK || !strings.Contains(body, `"service_name":"test-otel-debug"`) {
+			t.Errorf("expected build info, got %d %s", code, body)
+		}
+		if code, _ := get(t, base+PprofPath, "s3cret"); code != http.StatusNotFound {
+			t.Errorf("expected pprof to stay disabled, got %d", code)
+		}
+		if code, _ := get(t, base+"/metrics", ""); code != http.StatusOK {
+			t.Errorf("expected metrics to stay unauthenticated, got %d", code)
+		}
+	})
+
+	t.Run("Separate Address", func(t *testing.T) {
+		o, err := NewObservability(BaseConfig{
+			ServiceName:        "test-otel-debug",
+			Version:            "1.0.0",
+			TracingDisabled:    true,
+			MetricsMode:        "pull",
+			MetricsPath:        "/metrics",
+			MetricsPort:        0,
+			BuildInfoEnabled:   true,
+			DebugAddr:          "127.0.0.1:0",
+			OtelDisableGlobals: true,
+		})
+		if err != nil {
+			t.Fatalf("NewObservability failed: %v", err)
+		}
+		defer func() { _ = o.Shutdown(context.Background()) }()
+
+		if code, body := get(t, "http://"+o.DebugAddr()+BuildInfoPath, ""); code != http.StatusOK || !strings.Contains(body, `"service_name":"test-otel-debug"`) {
+			t.Errorf("expected build info on the debug server, got %d %s", code, body)
+		}
+		if code, _ := get(t, "http://"+o.MetricsAddr()+BuildInfoPath, ""); code != http.StatusNotFound {
+			t.Errorf("expected no debug endpoints on the metrics server, got %d", code)
+		}
+	})
+
+	t.Run("Port Only Binds Loopback", func(t *testing.T) {
+		o, err := NewObservability(BaseConfig{
+			ServiceName:        "test-otel-debug",
+			Version:            "1.0.0",
+			TracingDisabled:    true,
+			MetricsMode:        "none",
+			BuildInfoEnabled:   true,
+			DebugAddr:          ":0",
+			OtelDisableGlobals: true,
+		})
+		if err != nil {
+			t.Fatalf("NewObservability failed: %v", err)
+		}
+		defer func() { _ = o.Shutdown(context.Background()) }()
+
+		if addr := o.DebugAddr(); !strings.HasPrefix(addr, "127.0.0.1:") {
+			t.Errorf("expected the debug server bound to loopback, got %s", addr)
+		}
+	})
+}
+
+func TestNewObservability_ReleasesDebugServerOnError(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to reserve a port: %v", err)
+	}
+	addr := ln.Addr().String()
+	_ = ln.Close()
+
+	_, err = NewObservability(BaseConfig{
+		ServiceName:        "test-otel-debug",
+		Version:            "1.0.0",
+		TracingDisabled:    true,
+		MetricsMode:        "pull",
+		MetricsPath:        "/metrics",
+		MetricsPort:        0,
+		MetricsViews:       MetricViews{{Buckets: []float64{1}}},
+		BuildInfoEnabled:   true,
+		DebugAddr:          addr,
+		OtelDisableGlobals: true,
+	})
+	if err == nil {
+		t.Fatal("expected NewObservability to fail for an invalid view")
+	}
+
+	ln, err = net.Listen("tcp", addr)
+	if err != nil {
+		t.Fatalf("expected the debug address to be released, got %v", err)
+	}
+	_ = ln.Close()
+}
+
+func TestLoadCfgDebugEndpoints(t *testing.T) {
+	defer func() {
+		_ = os.Unsetenv("DEBUG_PPROF_ENABLED")
+		_ = os.Unsetenv("DEBUG_ADDR")
+		_ = os.Unsetenv("DEBUG_AUTH_TOKEN")
+		_ = os.Unsetenv("METRICS_PUSH_ENDPOINT")
+	}()
+
+	_ = os.Unsetenv("LOG_LEVEL")
+	_ = os.Setenv("SERVICE_NAME", "debug-service")
+	_ = os.Setenv("METRICS_PUSH_ENDPOINT", "localhost:4318")
+	_ = os.Setenv("DEBUG_PPROF_ENABLED", "true")
+
+	tests := []struct {
+		name    string
+		mode    string
+		addr    string
+		token   string
+		wantErr bool
+	}{
+		{name: "Pull Without Address", mode: "pull", addr: "", token: "s3cret", wantErr: false},
+		{name: "Pull Without Address Or Auth", mode: "pull", addr: "", token: "", wantErr: true},
+		{name: "Push With Address", mode: "push", addr: "127.0.0.1:6060", token: "s3cret", wantErr: false},
+		{name: "Port Only Without Auth", mode: "pull", addr: ":6060", token: "", wantErr: false},
+		{name: "Push Without Address", mode: "push", addr: "", token: "s3cret", wantErr: true},
+		{name: "Invalid Address", mode: "pull", addr: "localhost", token: "s3cret", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_ = os.Setenv("METRICS_MODE", tt.mode)
+			_ = os.Setenv("DEBUG_ADDR", tt.addr)
+			_ = os.Setenv("DEBUG_AUTH_TOKEN", tt.token)
+
+			var cfg BaseConfig
+			err := LoadCfg(&cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadCfg() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (!cfg.PprofEnabled || string(cfg.DebugAuthToken) != tt.token) {
+				t.Errorf("expected pprof enabled with the token, got %+v", cfg)
+			}
+		})
+	}
+}
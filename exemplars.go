@@ -0,0 +1,45 @@
+package observability
+
+import (
+	"strings"
+
+	"go.opentelemetry.io/otel/sdk/metric/exemplar"
+)
+
+// Exemplar filters selectable with METRICS_EXEMPLAR_FILTER
+const (
+	ExemplarFilterAlways     = "always"
+	ExemplarFilterTraceBased = "trace_based"
+	ExemplarFilterOff        = "off"
+)
+
+// exemplarFilterAliases maps the OTEL_METRICS_EXEMPLAR_FILTER spellings to the short names
+var exemplarFilterAliases = map[string]string{
+	"always_on":  ExemplarFilterAlways,
+	"always_off": ExemplarFilterOff,
+}
+
+// exemplarFilterName returns the normalized exemplar filter name. Empty means trace_based.
+func exemplarFilterName(s string) string {
+	name := strings.ToLower(strings.TrimSpace(s))
+	if alias, ok := exemplarFilterAliases[name]; ok {
+		return alias
+	}
+	if name == "" {
+		return ExemplarFilterTraceBased
+	}
+	return name
+}
+
+// exemplarFilter returns the filter deciding which measurements are offered as exemplars:
+// every measurement, those recorded in a sampled span (the default), or none
+func (b *BaseConfig) exemplarFilter() exemplar.Filter {
+	switch exemplarFilterName(b.ExemplarFilter) {
+	case ExemplarFilterAlways:
+		return exemplar.AlwaysOnFilter
+	case ExemplarFilterOff:
+		return exemplar.AlwaysOffFilter
+	default:
+		return exemplar.TraceBasedFilter
+	}
+}
@@ -0,0 +1,174 @@
+package observability
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// sampledContext returns a context carrying a sampled remote span context
+func sampledContext(t *testing.T) (context.Context, trace.SpanContext) {
+	t.Helper()
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+	return trace.ContextWithSpanContext(context.Background(), sc), sc
+}
+
+func TestExemplarFilterName(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "", want: ExemplarFilterTraceBased},
+		{input: "trace_based", want: ExemplarFilterTraceBased},
+		{input: "Always", want: ExemplarFilterAlways},
+		{input: "always_on", want: ExemplarFilterAlways},
+		{input: "off", want: ExemplarFilterOff},
+		{input: "always_off", want: ExemplarFilterOff},
+		{input: "sometimes", want: "sometimes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := exemplarFilterName(tt.input); got != tt.want {
+				t.Errorf("exemplarFilterName(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewObservability_OpenMetricsExemplars(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter string
+		want   bool
+	}{
+		{name: "Trace Based", filter: "trace_based", want: true},
+		{name: "Off", filter: "off", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o, err := NewObservability(BaseConfig{
+				ServiceName:        "test-otel-exemplars",
+				Version:            "1.0.0",
+				TracingDisabled:    true,
+				MetricsMode:        "pull",
+				MetricsPath:        "/metrics",
+				MetricsPort:        0,
+				ExemplarFilter:     tt.filter,
+				OtelDisableGlobals: true,
+			})
+			if err != nil {
+				t.Fatalf("NewObservability failed: %v", err)
+			}
+			defer func() { _ = o.Shutdown(context.Background()) }()
+
+			ctx, sc := sampledContext(t)
+			latency, _ := o.Meter("checkout").Float64Histogram("checkout.latency")
+			latency.Record(ctx, 0.3)
+			orders, _ := o.Meter("checkout").Int64Counter("checkout.orders")
+			orders.Add(ctx, 1)
+
+			req, _ := http.NewRequest(http.MethodGet, "http://"+o.MetricsAddr()+"/metrics", nil)
+			req.Header.Set("Accept", "application/openmetrics-text; version=1.0.0")
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("failed to scrape metrics: %v", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+			body, _ := io.ReadAll(resp.Body)
+
+			if !strings.HasPrefix(resp.Header.Get("Content-Type"), "application/openmetrics-text") {
+				t.Errorf("expected an OpenMetrics response, got %q", resp.Header.Get("Content-Type"))
+			}
+			exemplar := `trace_id="` + sc.TraceID().String() + `"`
+			for _, series := range []string{"checkout_latency_bucket", "checkout_orders_total"} {
+				found := false
+				for _, line := range strings.Split(string(body), "\n") {
+					if strings.HasPrefix(line, series) && strings.Contains(line, exemplar) {
+						found = true
+					}
+				}
+				if found != tt.want {
+					t.Errorf("expected exemplar on %s present=%v, got:\n%s", series, tt.want, body)
+				}
+			}
+		})
+	}
+}
+
+func TestFileMetricReaderExemplars(t *testing.T) {
+	dir := t.TempDir()
+	cfg := BaseConfig{FileExportDir: dir, MetricsPushInterval: 30, ExemplarFilter: "trace_based"}
+	reader, err := newFileMetricReader(context.Background(), cfg, metricPipeline{})
+	if err != nil {
+		t.Fatalf("newFileMetricReader failed: %v", err)
+	}
+
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader), sdkmetric.WithExemplarFilter(cfg.exemplarFilter()))
+	ctx, sc := sampledContext(t)
+	latency, _ := mp.Meter("checkout").Float64Histogram("checkout.latency")
+	latency.Record(ctx, 0.3)
+	if err := mp.Shutdown(context.Background()); err != nil {
+		t.Fatalf("meter provider shutdown failed: %v", err)
+	}
+
+	lines := readLines(t, filepath.Join(dir, "metrics.jsonl"))
+	if len(lines) != 1 || !strings.Contains(lines[0], `"exemplars"`) || !strings.Contains(lines[0], `"traceId":"`+sc.TraceID().String()+`"`) {
+		t.Errorf("expected an OTLP exemplar carrying the trace ID, got %v", lines)
+	}
+}
+
+func TestLoadCfgExemplarFilter(t *testing.T) {
+	defer func() {
+		_ = os.Unsetenv("METRICS_EXEMPLAR_FILTER")
+		_ = os.Unsetenv("OTEL_METRICS_EXEMPLAR_FILTER")
+	}()
+
+	_ = os.Unsetenv("LOG_LEVEL")
+	_ = os.Setenv("SERVICE_NAME", "exemplar-service")
+	_ = os.Setenv("METRICS_MODE", "pull")
+
+	tests := []struct {
+		name    string
+		env     string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{name: "Always", env: "METRICS_EXEMPLAR_FILTER", value: "always", want: "always", wantErr: false},
+		{name: "Standard Variable", env: "OTEL_METRICS_EXEMPLAR_FILTER", value: "always_off", want: "always_off", wantErr: false},
+		{name: "Invalid", env: "METRICS_EXEMPLAR_FILTER", value: "sometimes", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_ = os.Unsetenv("METRICS_EXEMPLAR_FILTER")
+			_ = os.Unsetenv("OTEL_METRICS_EXEMPLAR_FILTER")
+			_ = os.Setenv(tt.env, tt.value)
+
+			var cfg BaseConfig
+			err := LoadCfg(&cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadCfg() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && cfg.ExemplarFilter != tt.want {
+				t.Errorf("expected ExemplarFilter %q, got %q", tt.want, cfg.ExemplarFilter)
+			}
+		})
+	}
+}
@@ -0,0 +1,345 @@
+package observability
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// TracesExportFile selects the OTLP-JSON file trace exporter (TRACES_EXPORT)
+const TracesExportFile = "file"
+
+// File names of the OTLP-JSON file exporters inside FileExportDir
+const (
+	traceExportFileName  = "traces.jsonl"
+	metricExportFileName = "metrics.jsonl"
+)
+
+// otlpJSONIDFields are the OTLP JSON fields holding hex-encoded trace and span IDs
+var otlpJSONIDFields = map[string]bool{
+	"traceId":      true,
+	"spanId":       true,
+	"parentSpanId": true,
+}
+
+// isFileTraces returns true if spans are written to OTLP-JSON files
+func (b *BaseConfig) isFileTraces() bool {
+	return strings.ToLower(strings.TrimSpace(b.TracesExport)) == TracesExportFile
+}
+
+// IsFileMetrics returns true if MetricsMode is "file"
+func (b *BaseConfig) IsFileMetrics() bool {
+	mode := strings.ToLower(strings.TrimSpace(b.MetricsMode))
+	return mode == "file"
+}
+
+// newFileTraceExporter creates a span exporter appending one OTLP-JSON ExportTraceServiceRequest
+// per batch to FileExportDir/traces.jsonl. Batches are encoded by the OTLP HTTP exporter so the
+// files match what a collector receives.
+func newFileTraceExporter(ctx context.Context, cfg BaseConfig) (sdktrace.SpanExporter, error) {
+	file, err := newExportFile(cfg, traceExportFileName)
+	if err != nil {
+		return nil, err
+	}
+
+	exp, err := otlptracehttp.New(ctx,
+		otlptracehttp.WithHTTPClient(&http.Client{Transport: &otlpFileTransport{
+			file:       file,
+			newRequest: func() proto.Message { return &coltracepb.ExportTraceServiceRequest{} },
+		}}),
+		otlptracehttp.WithRetry(otlptracehttp.RetryConfig{Enabled: false}),
+	)
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("failed to create file trace exporter: %w", err)
+	}
+	return &fileSpanExporter{SpanExporter: exp, file: file}, nil
+}
+
+// newFileMetricReader creates a periodic reader appending one OTLP-JSON
+// ExportMetricsServiceRequest per collection to FileExportDir/metrics.jsonl
+func newFileMetricReader(ctx context.Context, cfg BaseConfig, pipeline metricPipeline) (*sdkmetric.PeriodicReader, error) {
+	file, err := newExportFile(cfg, metricExportFileName)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []otlpmetrichttp.Option{
+		otlpmetrichttp.WithHTTPClient(&http.Client{Transport: &otlpFileTransport{
+			file:       file,
+			newRequest: func() proto.Message { return &colmetricpb.ExportMetricsServiceRequest{} },
+		}}),
+		otlpmetrichttp.WithRetry(otlpmetrichttp.RetryConfig{Enabled: false}),
+	}
+	if selector := cfg.histogramAggregationSelector(); selector != nil {
+		opts = append(opts, otlpmetrichttp.WithAggregationSelector(selector))
+	}
+	exp, err := otlpmetrichttp.New(ctx, opts...)
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("failed to create file metrics exporter: %w", err)
+	}
+	return pipeline.periodicReader(&fileMetricExporter{Exporter: exp, file: file},
+		time.Duration(cfg.MetricsPushInterval)*time.Second,
+	), nil
+}
+
+// newExportFile opens the rotating file set for one signal
+func newExportFile(cfg BaseConfig, name string) (*rotatingFile, error) {
+	dir := strings.TrimSpace(cfg.FileExportDir)
+	if dir == "" {
+		dir = "."
+	}
+	file, err := newRotatingFile(filepath.Join(dir, name), int64(cfg.FileExportMaxSizeMB)*1024*1024, cfg.FileExportMaxFiles)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open export file: %w", err)
+	}
+	return file, nil
+}
+
+// fileSpanExporter closes the export file once the wrapped exporter has shut down
+type fileSpanExporter struct {
+	sdktrace.SpanExporter
+	file *rotatingFile
+}
+
+// Shutdown shuts down the exporter, then syncs and closes the file
+func (e *fileSpanExporter) Shutdown(ctx context.Context) error {
+	err := e.SpanExporter.Shutdown(ctx)
+	if cerr := e.file.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// fileMetricExporter closes the export file once the wrapped exporter has shut down
+type fileMetricExporter struct {
+	sdkmetric.Exporter
+	file *rotatingFile
+}
+
+// Shutdown shuts down the exporter, then syncs and closes the file
+func (e *fileMetricExporter) Shutdown(ctx context.Context) error {
+	err := e.Exporter.Shutdown(ctx)
+	if cerr := e.file.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// otlpFileTransport is an http.RoundTripper receiving OTLP/HTTP protobuf requests and appending
+// them to a file as OTLP JSON lines
+type otlpFileTransport struct {
+	file       *rotatingFile
+	newRequest func() proto.Message
+}
+
+// RoundTrip converts the request body to an OTLP JSON line and answers like a collector would
+func (t *otlpFileTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readOtlpBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := t.newRequest()
+	if err := proto.Unmarshal(body, msg); err != nil {
+		return nil, fmt.Errorf("failed to decode OTLP request: %w", err)
+	}
+	line, err := otlpJSON(msg)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.file.WriteLine(line); err != nil {
+		return nil, fmt.Errorf("failed to write export file: %w", err)
+	}
+
+	return &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{},
+		Body:       io.NopCloser(bytes.NewReader(nil)),
+		Request:    req,
+	}, nil
+}
+
+// readOtlpBody reads the request body, decompressing gzip-encoded requests
+func readOtlpBody(req *http.Request) ([]byte, error) {
+	defer func() { _ = req.Body.Close() }()
+
+	var r io.Reader = req.Body
+	if req.Header.Get("Content-Encoding") == "gzip" {
+		gz, err := gzip.NewReader(req.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress OTLP request: %w", err)
+		}
+		defer func() { _ = gz.Close() }()
+		r = gz
+	}
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OTLP request: %w", err)
+	}
+	return body, nil
+}
+
+// otlpJSON encodes an OTLP message following the OTLP/HTTP JSON mapping: enums as numbers and
+// trace/span IDs as hex strings (protojson writes bytes as base64)
+func otlpJSON(msg proto.Message) ([]byte, error) {
+	raw, err := protojson.MarshalOptions{UseEnumNumbers: true}.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode OTLP JSON: %w", err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to encode OTLP JSON: %w", err)
+	}
+	hexEncodeIDs(doc)
+
+	out, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode OTLP JSON: %w", err)
+	}
+	return out, nil
+}
+
+// hexEncodeIDs rewrites base64 trace and span IDs in a decoded JSON document to hex
+func hexEncodeIDs(v interface{}) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, item := range val {
+			if s, ok := item.(string); ok && otlpJSONIDFields[k] {
+				if b, err := base64.StdEncoding.DecodeString(s); err == nil {
+					val[k] = hex.EncodeToString(b)
+				}
+				continue
+			}
+			hexEncodeIDs(item)
+		}
+	case []interface{}:
+		for _, item := range val {
+			hexEncodeIDs(item)
+		}
+	}
+}
+
+// rotatingFile appends lines to a file, rotating it to path.1 ... path.N once maxBytes would be
+// exceeded and keeping at most maxFiles rotated files. A maxBytes of 0 disables rotation.
+type rotatingFile struct {
+	mu       sync.Mutex
+	path     string
+	maxBytes int64
+	maxFiles int
+	f        *os.File
+	size     int64
+}
+
+// newRotatingFile opens (or creates) path for appending, creating its directory if needed
+func newRotatingFile(path string, maxBytes int64, maxFiles int) (*rotatingFile, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	r := &rotatingFile{path: path, maxBytes: maxBytes, maxFiles: maxFiles}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// open opens the current file in append mode and records its size
+func (r *rotatingFile) open() error {
+	f, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+	r.f = f
+	r.size = info.Size()
+	return nil
+}
+
+// WriteLine appends line and a newline. A line is never split across files.
+func (r *rotatingFile) WriteLine(line []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.f == nil {
+		return os.ErrClosed
+	}
+	n := int64(len(line)) + 1
+	if r.maxBytes > 0 && r.size > 0 && r.size+n > r.maxBytes {
+		if err := r.rotate(); err != nil {
+			return err
+		}
+	}
+
+	written, err := r.f.Write(append(line, '\n'))
+	r.size += int64(written)
+	return err
+}
+
+// rotate closes the current file, shifts rotated files by one and drops those beyond maxFiles
+func (r *rotatingFile) rotate() error {
+	if err := r.f.Close(); err != nil {
+		return err
+	}
+	r.f = nil
+
+	_ = os.Remove(fmt.Sprintf("%s.%d", r.path, r.maxFiles))
+	for i := r.maxFiles - 1; i >= 1; i-- {
+		_ = os.Rename(fmt.Sprintf("%s.%d", r.path, i), fmt.Sprintf("%s.%d", r.path, i+1))
+	}
+	if r.maxFiles > 0 {
+		if err := os.Rename(r.path, r.path+".1"); err != nil {
+			return err
+		}
+	} else if err := os.Remove(r.path); err != nil {
+		return err
+	}
+	return r.open()
+}
+
+// Close syncs and closes the file. Further writes fail.
+func (r *rotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.f == nil {
+		return nil
+	}
+	err := r.f.Sync()
+	if cerr := r.f.Close(); err == nil {
+		err = cerr
+	}
+	r.f = nil
+	return err
+}
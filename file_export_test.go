@@ -0,0 +1,200 @@
+package observability
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// readLines returns the lines of a file
+func readLines(t *testing.T, path string) []string {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open %s: %v", path, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 1024*1024), 16*1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines
+}
+
+func TestFileTraceExporter(t *testing.T) {
+	dir := t.TempDir()
+	exp, err := newFileTraceExporter(context.Background(), BaseConfig{FileExportDir: dir, FileExportMaxSizeMB: 1, FileExportMaxFiles: 2})
+	if err != nil {
+		t.Fatalf("newFileTraceExporter failed: %v", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exp))
+	ctx, parent := tp.Tracer("file-test").Start(context.Background(), "parent")
+	_, child := tp.Tracer("file-test").Start(ctx, "child")
+	child.End()
+	parent.End()
+
+	// Shutdown flushes pending spans and closes the file
+	if err := tp.Shutdown(context.Background()); err != nil {
+		t.Fatalf("tracer provider shutdown failed: %v", err)
+	}
+
+	lines := readLines(t, filepath.Join(dir, "traces.jsonl"))
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 batch line, got %d", len(lines))
+	}
+
+	var doc struct {
+		ResourceSpans []struct {
+			ScopeSpans []struct {
+				Spans []struct {
+					TraceID      string `json:"traceId"`
+					SpanID       string `json:"spanId"`
+					ParentSpanID string `json:"parentSpanId"`
+					Name         string `json:"name"`
+					Kind         int    `json:"kind"`
+				} `json:"spans"`
+			} `json:"scopeSpans"`
+		} `json:"resourceSpans"`
+	}
+	if err := json.Unmarshal([]byte(lines[0]), &doc); err != nil {
+		t.Fatalf("invalid JSON line: %v", err)
+	}
+	spans := doc.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	child0 := spans[0]
+	if child0.Name != "child" || child0.TraceID != parent.SpanContext().TraceID().String() ||
+		child0.ParentSpanID != parent.SpanContext().SpanID().String() {
+		t.Errorf("expected hex trace/span IDs matching the parent span, got %+v", child0)
+	}
+	if child0.Kind != 1 {
+		t.Errorf("expected numeric span kind, got %d", child0.Kind)
+	}
+}
+
+func TestFileMetricReader(t *testing.T) {
+	dir := t.TempDir()
+	reader, err := newFileMetricReader(context.Background(), BaseConfig{FileExportDir: dir, MetricsPushInterval: 30}, metricPipeline{})
+	if err != nil {
+		t.Fatalf("newFileMetricReader failed: %v", err)
+	}
+
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	counter, err := mp.Meter("file-test").Int64Counter("orders_total")
+	if err != nil {
+		t.Fatalf("failed to create counter: %v", err)
+	}
+	counter.Add(context.Background(), 7)
+
+	if err := mp.Shutdown(context.Background()); err != nil {
+		t.Fatalf("meter provider shutdown failed: %v", err)
+	}
+
+	lines := readLines(t, filepath.Join(dir, "metrics.jsonl"))
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 snapshot line, got %d", len(lines))
+	}
+
+	var req colmetricpb.ExportMetricsServiceRequest
+	if err := protojson.Unmarshal([]byte(lines[0]), &req); err != nil {
+		t.Fatalf("expected an OTLP JSON metrics request, got error: %v", err)
+	}
+	metric := req.ResourceMetrics[0].ScopeMetrics[0].Metrics[0]
+	if metric.Name != "orders_total" || metric.GetSum().DataPoints[0].GetAsInt() != 7 {
+		t.Errorf("unexpected metric in file: %v", metric)
+	}
+}
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "signal.jsonl")
+	f, err := newRotatingFile(path, 10, 2)
+	if err != nil {
+		t.Fatalf("newRotatingFile failed: %v", err)
+	}
+
+	for _, line := range []string{"first", "second", "third", "fourth"} {
+		if err := f.WriteLine([]byte(line)); err != nil {
+			t.Fatalf("WriteLine failed: %v", err)
+		}
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	expected := map[string]string{
+		path:        "fourth",
+		path + ".1": "third",
+		path + ".2": "second",
+	}
+	for p, want := range expected {
+		lines := readLines(t, p)
+		if len(lines) != 1 || lines[0] != want {
+			t.Errorf("expected %s to hold %q, got %v", filepath.Base(p), want, lines)
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Error("expected rotated files beyond the retention count to be removed")
+	}
+	if err := f.WriteLine([]byte("late")); err == nil {
+		t.Error("expected writes after Close to fail")
+	}
+}
+
+func TestNewObservabilityFileExport(t *testing.T) {
+	dir := t.TempDir()
+	cfg := BaseConfig{
+		ServiceName:           "test-otel-file",
+		Version:               "1.0.0",
+		TracesExport:          "file",
+		OtelTracingSampleRate: 1.0,
+		MetricsMode:           "file",
+		MetricsPushInterval:   30,
+		FileExportDir:         dir,
+		FileExportMaxSizeMB:   10,
+		FileExportMaxFiles:    3,
+		OtelDisableGlobals:    true,
+	}
+
+	o, err := NewObservability(cfg)
+	if err != nil {
+		t.Fatalf("NewObservability in file mode failed: %v", err)
+	}
+	if o.MetricsAddr() != "" {
+		t.Error("expected no metrics server in file mode")
+	}
+
+	_, span := o.Tracer("file").Start(context.Background(), "soak")
+	span.End()
+	counter, _ := o.Meter("file").Int64Counter("soak_iterations")
+	counter.Add(context.Background(), 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	_ = o.Shutdown(ctx)
+
+	for _, name := range []string{"traces.jsonl", "metrics.jsonl"} {
+		lines := readLines(t, filepath.Join(dir, name))
+		if len(lines) == 0 {
+			t.Errorf("expected %s to be written on shutdown", name)
+			continue
+		}
+		if !strings.Contains(lines[0], `"test-otel-file"`) {
+			t.Errorf("expected the resource in %s, got %s", name, lines[0])
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -19,6 +20,36 @@ type ObservabilityMiddlewareConfig struct {
 	// SkipRoute is a custom predicate function to determine if a route should be skipped
 	// If both ExcludedPaths and SkipRoute are set, SkipRoute takes precedence
 	SkipRoute func(path string) bool
+	// TracerProvider creates the middleware tracers. Defaults to the global provider.
+	TracerProvider trace.TracerProvider
+	// MeterProvider creates the middleware instruments. Defaults to the global provider.
+	MeterProvider metric.MeterProvider
+	// Propagator extracts and injects trace context. Defaults to the global propagator.
+	Propagator propagation.TextMapPropagator
+}
+
+// tracer returns a tracer from the configured provider, falling back to the global one
+func (c *ObservabilityMiddlewareConfig) tracer(name string) trace.Tracer {
+	if c != nil && c.TracerProvider != nil {
+		return c.TracerProvider.Tracer(name)
+	}
+	return otel.Tracer(name)
+}
+
+// meter returns a meter from the configured provider, falling back to the global one
+func (c *ObservabilityMiddlewareConfig) meter(name string) metric.Meter {
+	if c != nil && c.MeterProvider != nil {
+		return c.MeterProvider.Meter(name)
+	}
+	return otel.Meter(name)
+}
+
+// propagator returns the configured propagator, falling back to the global one
+func (c *ObservabilityMiddlewareConfig) propagator() propagation.TextMapPropagator {
+	if c != nil && c.Propagator != nil {
+		return c.Propagator
+	}
+	return otel.GetTextMapPropagator()
 }
 
 // shouldSkipRoute checks if a path should be skipped based on configuration
@@ -49,8 +80,8 @@ func GinTracing(serviceName string) gin.HandlerFunc {
 
 // GinTracingWithConfig middleware creates OpenTelemetry spans for HTTP requests with skip configuration
 func GinTracingWithConfig(serviceName string, cfg *ObservabilityMiddlewareConfig) gin.HandlerFunc {
-	tracer := otel.Tracer("gin-server")
-	propagator := otel.GetTextMapPropagator()
+	tracer := cfg.tracer("gin-server")
+	propagator := cfg.propagator()
 
 	return func(c *gin.Context) {
 		// Check if this path should be skipped
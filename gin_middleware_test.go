@@ -9,6 +9,9 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -351,7 +354,7 @@ func TestGinTracingWithExcludedPaths(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			routesCalled[tt.path] = false
-			
+
 			router := gin.New()
 			router.Use(GinTracingWithConfig("test-service", middlewareCfg))
 
@@ -506,4 +509,41 @@ func TestGinTracing(t *testing.T) {
 	// In test environments without a tracer provider the header may be empty.
 	traceID := w.Header().Get("X-Trace-ID")
 	t.Logf("X-Trace-ID header: %s", traceID)
-}
\ No newline at end of file
+}
+
+func TestGinTracingWithExplicitProvider(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// The global provider must not see spans when an explicit provider is configured
+	globalSR := installTestTracing(t)
+
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	cfg := &ObservabilityMiddlewareConfig{
+		TracerProvider: tp,
+		Propagator:     propagation.TraceContext{},
+	}
+
+	router := gin.New()
+	router.Use(GinTracingWithConfig("test-service", cfg))
+	router.GET("/explicit", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	parentTraceID := "4bf92f3577b34da6a3ce929d0e0e4736"
+	req, _ := http.NewRequest(http.MethodGet, "/explicit", nil)
+	req.Header.Set("traceparent", "00-"+parentTraceID+"-00f067aa0ba902b7-01")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	spans := sr.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span on the explicit provider, got %d", len(spans))
+	}
+	if got := spans[0].SpanContext().TraceID().String(); got != parentTraceID {
+		t.Errorf("expected trace id %s extracted by the explicit propagator, got %s", parentTraceID, got)
+	}
+	if len(globalSR.Ended()) != 0 {
+		t.Errorf("expected no spans on the global provider, got %d", len(globalSR.Ended()))
+	}
+}
@@ -0,0 +1,271 @@
+package observability
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// grpcClientTelemetry holds the tracer, propagator and instruments shared by client interceptors
+type grpcClientTelemetry struct {
+	logger     *Logger
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+	duration   metric.Float64Histogram
+}
+
+func newGrpcClientTelemetry(logger *Logger, cfg *ObservabilityMiddlewareConfig) *grpcClientTelemetry {
+	duration, err := cfg.meter("grpc-client").Float64Histogram(
+		"rpc.client.duration",
+		metric.WithDescription("Measures the duration of outbound RPC"),
+		metric.WithUnit("ms"),
+	)
+	if err != nil {
+		logger.Warn("failed to create rpc.client.duration histogram", "error", err)
+		duration = noop.Float64Histogram{}
+	}
+
+	return &grpcClientTelemetry{
+		logger:     logger,
+		tracer:     cfg.tracer("grpc-client"),
+		propagator: cfg.propagator(),
+		duration:   duration,
+	}
+}
+
+// start creates a client span and injects its context into the outgoing metadata
+func (t *grpcClientTelemetry) start(ctx context.Context, fullMethod string) (context.Context, trace.Span) {
+	ctx, span := t.tracer.Start(ctx, grpcSpanName(fullMethod),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(grpcSpanAttributes(fullMethod)...),
+	)
+
+	// Copy outgoing metadata so the caller's MD is not mutated
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+	t.propagator.Inject(ctx, metadataCarrier(md))
+
+	return metadata.NewOutgoingContext(ctx, md), span
+}
+
+// finish records span status, duration metrics and the log line for a completed call
+func (t *grpcClientTelemetry) finish(ctx context.Context, span trace.Span, fullMethod string, start time.Time, err error, extra ...interface{}) {
+	latency := time.Since(start)
+	grpcStatus := status.Code(err)
+
+	// Record span status (any non-OK code is an error on the client side)
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(grpcStatus)))
+	if grpcStatus != codes.OK {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, status.Convert(err).Message())
+	}
+
+	// Record duration histogram
+	attrs := append(grpcSpanAttributes(fullMethod), semconv.RPCGRPCStatusCodeKey.Int(int(grpcStatus)))
+	t.duration.Record(ctx, float64(latency)/float64(time.Millisecond), metric.WithAttributeSet(attribute.NewSet(attrs...)))
+
+	spanContext := span.SpanContext()
+	traceID := spanContext.TraceID().String()
+	spanID := spanContext.SpanID().String()
+
+	// Build log fields
+	fields := []interface{}{
+		"method", fullMethod,
+		"grpc_code", grpcStatus.String(),
+		"latency_ms", latency.Milliseconds(),
+	}
+	fields = append(fields, extra...)
+
+	// Add trace context if present
+	if traceID != "" && traceID != "00000000000000000000000000000000" {
+		fields = append(fields, "trace_id", traceID)
+	}
+	if spanID != "" && spanID != "0000000000000000" {
+		fields = append(fields, "span_id", spanID)
+	}
+
+	// Add error if present
+	if err != nil {
+		fields = append(fields, "error", err.Error())
+	}
+
+	// Log based on gRPC status code
+	switch {
+	case grpcStatus == codes.OK:
+		t.logger.Info("gRPC Client Call", fields...)
+	case isGrpcClientError(grpcStatus):
+		t.logger.Warn("gRPC Client Call Client Error", fields...)
+	default:
+		t.logger.Error("gRPC Client Call Server Error", fields...)
+	}
+}
+
+// GrpcUnaryClientInterceptor propagates trace context, creates client spans, logs and records
+// duration metrics for outgoing gRPC unary calls
+func GrpcUnaryClientInterceptor(logger *Logger) grpc.UnaryClientInterceptor {
+	return GrpcUnaryClientInterceptorWithConfig(logger, nil)
+}
+
+// GrpcUnaryClientInterceptorWithConfig instruments outgoing gRPC unary calls using the providers
+// and propagator from cfg
+func GrpcUnaryClientInterceptorWithConfig(logger *Logger, cfg *ObservabilityMiddlewareConfig) grpc.UnaryClientInterceptor {
+	tel := newGrpcClientTelemetry(logger, cfg)
+
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		start := time.Now()
+
+		ctx, span := tel.start(ctx, method)
+		defer span.End()
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		tel.finish(ctx, span, method, start, err)
+
+		return err
+	}
+}
+
+// GrpcStreamClientInterceptor propagates trace context, creates client spans, logs and records
+// duration metrics for outgoing gRPC streaming calls. The span ends when the stream completes.
+func GrpcStreamClientInterceptor(logger *Logger) grpc.StreamClientInterceptor {
+	return GrpcStreamClientInterceptorWithConfig(logger, nil)
+}
+
+// GrpcStreamClientInterceptorWithConfig instruments outgoing gRPC streaming calls using the
+// providers and propagator from cfg
+func GrpcStreamClientInterceptorWithConfig(logger *Logger, cfg *ObservabilityMiddlewareConfig) grpc.StreamClientInterceptor {
+	tel := newGrpcClientTelemetry(logger, cfg)
+
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		start := time.Now()
+
+		ctx, span := tel.start(ctx, method)
+
+		extra := []interface{}{
+			"is_client_stream", desc.ClientStreams,
+			"is_server_stream", desc.ServerStreams,
+		}
+
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			tel.finish(ctx, span, method, start, err, extra...)
+			span.End()
+			return nil, err
+		}
+
+		s := &tracedClientStream{
+			ClientStream: stream,
+			serverStream: desc.ServerStreams,
+			done:         make(chan struct{}),
+		}
+		s.onFinish = func(err error) {
+			tel.finish(ctx, span, method, start, err, extra...)
+			span.End()
+		}
+
+		// End the span if the caller abandons the stream through context cancellation
+		go func() {
+			select {
+			case <-ctx.Done():
+				s.finish(status.FromContextError(ctx.Err()).Err())
+			case <-s.done:
+			}
+		}()
+
+		return s, nil
+	}
+}
+
+// tracedClientStream ends the client span once the stream is finished
+type tracedClientStream struct {
+	grpc.ClientStream
+	serverStream bool
+	onFinish     func(error)
+	once         sync.Once
+	done         chan struct{}
+}
+
+func (s *tracedClientStream) finish(err error) {
+	s.once.Do(func() {
+		close(s.done)
+		s.onFinish(err)
+	})
+}
+
+// RecvMsg finishes the stream on io.EOF, on error, or after the single response of a client stream
+func (s *tracedClientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	switch {
+	case errors.Is(err, io.EOF):
+		s.finish(nil)
+	case err != nil:
+		s.finish(err)
+	case !s.serverStream:
+		s.finish(nil)
+	}
+	return err
+}
+
+// SendMsg finishes the stream when sending fails with anything other than io.EOF
+func (s *tracedClientStream) SendMsg(m interface{}) error {
+	err := s.ClientStream.SendMsg(m)
+	if err != nil && !errors.Is(err, io.EOF) {
+		s.finish(err)
+	}
+	return err
+}
+
+// Header finishes the stream when headers cannot be received
+func (s *tracedClientStream) Header() (metadata.MD, error) {
+	md, err := s.ClientStream.Header()
+	if err != nil {
+		s.finish(err)
+	}
+	return md, err
+}
+
+// GrpcClientInterceptors returns dial options chaining the unary and stream client interceptors
+// Usage: grpc.NewClient(target, append(opts, observability.GrpcClientInterceptors(logger)...)...)
+func GrpcClientInterceptors(logger *Logger) []grpc.DialOption {
+	return GrpcClientInterceptorsWithConfig(logger, nil)
+}
+
+// GrpcClientInterceptorsWithConfig returns the client interceptor dial options using the providers
+// and propagator from cfg
+func GrpcClientInterceptorsWithConfig(logger *Logger, cfg *ObservabilityMiddlewareConfig) []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(GrpcUnaryClientInterceptorWithConfig(logger, cfg)),
+		grpc.WithChainStreamInterceptor(GrpcStreamClientInterceptorWithConfig(logger, cfg)),
+	}
+}
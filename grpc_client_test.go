@@ -0,0 +1,205 @@
+package observability
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// installTestMetrics installs a manual metric reader as the global meter provider for the test duration
+func installTestMetrics(t *testing.T) *sdkmetric.ManualReader {
+	t.Helper()
+
+	origMP := otel.GetMeterProvider()
+	reader := sdkmetric.NewManualReader()
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+
+	t.Cleanup(func() { otel.SetMeterProvider(origMP) })
+	return reader
+}
+
+// mockClientStream is a ClientStream returning a fixed error from RecvMsg
+type mockClientStream struct {
+	grpc.ClientStream
+	recvErr error
+}
+
+func (m *mockClientStream) RecvMsg(msg interface{}) error { return m.recvErr }
+
+func TestGrpcUnaryClientInterceptor(t *testing.T) {
+	sr := installTestTracing(t)
+	reader := installTestMetrics(t)
+	logger := NewLogger(&BaseConfig{ServiceName: "test-grpc-client", LogLevel: "info"})
+
+	interceptor := GrpcUnaryClientInterceptor(logger)
+
+	var outgoing metadata.MD
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		outgoing, _ = metadata.FromOutgoingContext(ctx)
+		return status.Error(codes.Unavailable, "down")
+	}
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-request-id", "abc")
+	err := interceptor(ctx, "/test.Service/Call", &mockRequest{}, &mockResponse{}, nil, invoker)
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected Unavailable error, got %v", err)
+	}
+
+	spans := sr.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.SpanKind() != trace.SpanKindClient {
+		t.Errorf("expected client span, got %v", span.SpanKind())
+	}
+	if span.Status().Code != otelcodes.Error {
+		t.Errorf("expected error span status, got %v", span.Status().Code)
+	}
+
+	// Trace context and existing metadata must both be present on the outgoing call
+	if got := outgoing.Get("x-request-id"); len(got) != 1 || got[0] != "abc" {
+		t.Errorf("expected existing metadata to be preserved, got %v", got)
+	}
+	traceparent := outgoing.Get("traceparent")
+	if len(traceparent) != 1 {
+		t.Fatalf("expected traceparent in outgoing metadata, got %v", outgoing)
+	}
+	expected := "00-" + span.SpanContext().TraceID().String() + "-" + span.SpanContext().SpanID().String() + "-01"
+	if traceparent[0] != expected {
+		t.Errorf("expected traceparent %s, got %s", expected, traceparent[0])
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	found := false
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "rpc.client.duration" {
+				continue
+			}
+			hist, ok := m.Data.(metricdata.Histogram[float64])
+			if !ok || len(hist.DataPoints) != 1 || hist.DataPoints[0].Count != 1 {
+				t.Errorf("unexpected rpc.client.duration data: %#v", m.Data)
+			}
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected rpc.client.duration histogram to be recorded")
+	}
+}
+
+func TestGrpcStreamClientInterceptor(t *testing.T) {
+	logger := NewLogger(&BaseConfig{ServiceName: "test-grpc-client", LogLevel: "info"})
+
+	tests := []struct {
+		name       string
+		recvErr    error
+		wantStatus otelcodes.Code
+	}{
+		{name: "EOF_Ends_OK", recvErr: io.EOF, wantStatus: otelcodes.Unset},
+		{name: "Error_Ends_With_Error", recvErr: status.Error(codes.Internal, "broken"), wantStatus: otelcodes.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sr := installTestTracing(t)
+			interceptor := GrpcStreamClientInterceptor(logger)
+
+			streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+				return &mockClientStream{recvErr: tt.recvErr}, nil
+			}
+
+			desc := &grpc.StreamDesc{ServerStreams: true}
+			stream, err := interceptor(context.Background(), desc, nil, "/test.Service/Watch", streamer)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if len(sr.Ended()) != 0 {
+				t.Fatal("expected span to stay open until the stream finishes")
+			}
+
+			_ = stream.RecvMsg(&mockResponse{})
+
+			spans := sr.Ended()
+			if len(spans) != 1 {
+				t.Fatalf("expected 1 span after stream finished, got %d", len(spans))
+			}
+			if spans[0].Status().Code != tt.wantStatus {
+				t.Errorf("expected span status %v, got %v", tt.wantStatus, spans[0].Status().Code)
+			}
+		})
+	}
+}
+
+func TestGrpcClientInterceptors(t *testing.T) {
+	logger := NewLogger(&BaseConfig{ServiceName: "test-grpc-client", LogLevel: "info"})
+
+	opts := GrpcClientInterceptors(logger)
+	if len(opts) != 2 {
+		t.Errorf("Expected 2 dial options, got %d", len(opts))
+	}
+}
+
+func TestGrpcUnaryClientInterceptorWithExplicitProviders(t *testing.T) {
+	globalSR := installTestTracing(t)
+	globalReader := installTestMetrics(t)
+	logger := NewLogger(&BaseConfig{ServiceName: "test-grpc-client", LogLevel: "info"})
+
+	sr := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	cfg := &ObservabilityMiddlewareConfig{
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr)),
+		MeterProvider:  sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+		Propagator:     propagation.TraceContext{},
+	}
+
+	interceptor := GrpcUnaryClientInterceptorWithConfig(logger, cfg)
+
+	var outgoing metadata.MD
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		outgoing, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	}
+	if err := interceptor(context.Background(), "/test.Service/Call", &mockRequest{}, &mockResponse{}, nil, invoker); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := len(sr.Ended()); got != 1 {
+		t.Fatalf("expected 1 span on the explicit provider, got %d", got)
+	}
+	if got := len(globalSR.Ended()); got != 0 {
+		t.Errorf("expected no spans on the global provider, got %d", got)
+	}
+	if len(outgoing.Get("traceparent")) != 1 {
+		t.Errorf("expected traceparent injected by the explicit propagator, got %v", outgoing)
+	}
+
+	for name, r := range map[string]*sdkmetric.ManualReader{"explicit": reader, "global": globalReader} {
+		var rm metricdata.ResourceMetrics
+		if err := r.Collect(context.Background(), &rm); err != nil {
+			t.Fatalf("collect failed: %v", err)
+		}
+		recorded := len(rm.ScopeMetrics) > 0
+		if recorded != (name == "explicit") {
+			t.Errorf("unexpected metrics on the %s meter provider: %v", name, rm.ScopeMetrics)
+		}
+	}
+}
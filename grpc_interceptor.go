@@ -13,6 +13,18 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+// isGrpcClientError reports whether a status code is caused by the caller and should be logged as a warning
+func isGrpcClientError(code codes.Code) bool {
+	switch code {
+	case codes.Canceled, codes.InvalidArgument, codes.NotFound, codes.AlreadyExists,
+		codes.PermissionDenied, codes.Unauthenticated, codes.FailedPrecondition,
+		codes.OutOfRange:
+		return true
+	default:
+		return false
+	}
+}
+
 // GrpcUnaryServerInterceptor logs gRPC unary requests with OpenTelemetry trace context
 func GrpcUnaryServerInterceptor(logger *Logger) grpc.UnaryServerInterceptor {
 	return func(
@@ -59,12 +71,10 @@ func GrpcUnaryServerInterceptor(logger *Logger) grpc.UnaryServerInterceptor {
 		}
 
 		// Log based on gRPC status code
-		switch grpcStatus {
-		case codes.OK:
+		switch {
+		case grpcStatus == codes.OK:
 			logger.Info("gRPC Request", fields...)
-		case codes.Canceled, codes.InvalidArgument, codes.NotFound, codes.AlreadyExists,
-			codes.PermissionDenied, codes.Unauthenticated, codes.FailedPrecondition,
-			codes.OutOfRange:
+		case isGrpcClientError(grpcStatus):
 			logger.Warn("gRPC Client Error", fields...)
 		default:
 			logger.Error("gRPC Server Error", fields...)
@@ -123,12 +133,10 @@ func GrpcStreamServerInterceptor(logger *Logger) grpc.StreamServerInterceptor {
 		}
 
 		// Log based on gRPC status code
-		switch grpcStatus {
-		case codes.OK:
+		switch {
+		case grpcStatus == codes.OK:
 			logger.Info("gRPC Stream Request", fields...)
-		case codes.Canceled, codes.InvalidArgument, codes.NotFound, codes.AlreadyExists,
-			codes.PermissionDenied, codes.Unauthenticated, codes.FailedPrecondition,
-			codes.OutOfRange:
+		case isGrpcClientError(grpcStatus):
 			logger.Warn("gRPC Stream Client Error", fields...)
 		default:
 			logger.Error("gRPC Stream Server Error", fields...)
@@ -234,19 +242,33 @@ func GrpcStreamRecoveryInterceptor(logger *Logger) grpc.StreamServerInterceptor
 	}
 }
 
-// GrpcUnaryInterceptors returns a chain of unary interceptors (recovery + logging)
+// GrpcUnaryInterceptors returns a chain of unary interceptors (tracing + recovery + logging)
 // Usage: grpc.NewServer(grpc.ChainUnaryInterceptor(observability.GrpcUnaryInterceptors(logger)...))
 func GrpcUnaryInterceptors(logger *Logger) []grpc.UnaryServerInterceptor {
+	return GrpcUnaryInterceptorsWithConfig(logger, nil)
+}
+
+// GrpcUnaryInterceptorsWithConfig returns the unary interceptor chain with the tracing provider
+// and propagator taken from cfg
+func GrpcUnaryInterceptorsWithConfig(logger *Logger, cfg *ObservabilityMiddlewareConfig) []grpc.UnaryServerInterceptor {
 	return []grpc.UnaryServerInterceptor{
+		GrpcUnaryTracingInterceptorWithConfig(cfg),
 		GrpcUnaryRecoveryInterceptor(logger),
 		GrpcUnaryServerInterceptor(logger),
 	}
 }
 
-// GrpcStreamInterceptors returns a chain of stream interceptors (recovery + logging)
+// GrpcStreamInterceptors returns a chain of stream interceptors (tracing + recovery + logging)
 // Usage: grpc.NewServer(grpc.ChainStreamInterceptor(observability.GrpcStreamInterceptors(logger)...))
 func GrpcStreamInterceptors(logger *Logger) []grpc.StreamServerInterceptor {
+	return GrpcStreamInterceptorsWithConfig(logger, nil)
+}
+
+// GrpcStreamInterceptorsWithConfig returns the stream interceptor chain with the tracing provider
+// and propagator taken from cfg
+func GrpcStreamInterceptorsWithConfig(logger *Logger, cfg *ObservabilityMiddlewareConfig) []grpc.StreamServerInterceptor {
 	return []grpc.StreamServerInterceptor{
+		GrpcStreamTracingInterceptorWithConfig(cfg),
 		GrpcStreamRecoveryInterceptor(logger),
 		GrpcStreamServerInterceptor(logger),
 	}
@@ -444,8 +444,8 @@ func TestGrpcUnaryInterceptors(t *testing.T) {
 
 	interceptors := GrpcUnaryInterceptors(logger)
 
-	if len(interceptors) != 2 {
-		t.Errorf("Expected 2 interceptors, got %d", len(interceptors))
+	if len(interceptors) != 3 {
+		t.Errorf("Expected 3 interceptors, got %d", len(interceptors))
 	}
 
 	// Test that interceptors are not nil
@@ -466,8 +466,8 @@ func TestGrpcStreamInterceptors(t *testing.T) {
 
 	interceptors := GrpcStreamInterceptors(logger)
 
-	if len(interceptors) != 2 {
-		t.Errorf("Expected 2 interceptors, got %d", len(interceptors))
+	if len(interceptors) != 3 {
+		t.Errorf("Expected 3 interceptors, got %d", len(interceptors))
 	}
 
 	// Test that interceptors are not nil
@@ -0,0 +1,179 @@
+package observability
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// metadataCarrier adapts gRPC metadata to the OpenTelemetry TextMapCarrier interface
+type metadataCarrier metadata.MD
+
+// Get returns the first value for the given key
+func (c metadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// Set stores a key-value pair, replacing any existing values
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+// Keys lists the keys stored in the carrier
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// splitGrpcMethod splits "/package.Service/Method" into service and method names
+func splitGrpcMethod(fullMethod string) (string, string) {
+	name := strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		return name[:i], name[i+1:]
+	}
+	return name, ""
+}
+
+// grpcSpanName returns the span name for a gRPC method ("package.Service/Method")
+func grpcSpanName(fullMethod string) string {
+	return strings.TrimPrefix(fullMethod, "/")
+}
+
+// grpcSpanAttributes returns the RPC semantic-convention attributes for a method
+func grpcSpanAttributes(fullMethod string) []attribute.KeyValue {
+	service, method := splitGrpcMethod(fullMethod)
+	attrs := []attribute.KeyValue{semconv.RPCSystemGRPC}
+	if service != "" {
+		attrs = append(attrs, semconv.RPCService(service))
+	}
+	if method != "" {
+		attrs = append(attrs, semconv.RPCMethod(method))
+	}
+	return attrs
+}
+
+// isGrpcServerError reports whether a status code indicates a server-side failure.
+// Client-caused codes (NotFound, InvalidArgument, ...) leave the server span unset.
+func isGrpcServerError(code codes.Code) bool {
+	switch code {
+	case codes.Unknown, codes.DeadlineExceeded, codes.Unimplemented, codes.Internal,
+		codes.Unavailable, codes.DataLoss:
+		return true
+	default:
+		return false
+	}
+}
+
+// finishGrpcServerSpan records the gRPC status on a server span
+func finishGrpcServerSpan(span trace.Span, err error) {
+	s, _ := status.FromError(err)
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(s.Code())))
+	if isGrpcServerError(s.Code()) {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, s.Message())
+	}
+}
+
+// tracedServerStream overrides the context of a ServerStream so handlers see the server span
+type tracedServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context carrying the server span
+func (s *tracedServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// extractIncoming extracts W3C trace context from incoming gRPC metadata
+func extractIncoming(ctx context.Context, propagator propagation.TextMapPropagator) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	return propagator.Extract(ctx, metadataCarrier(md))
+}
+
+// GrpcUnaryTracingInterceptor creates OpenTelemetry server spans for gRPC unary requests
+func GrpcUnaryTracingInterceptor() grpc.UnaryServerInterceptor {
+	return GrpcUnaryTracingInterceptorWithConfig(nil)
+}
+
+// GrpcUnaryTracingInterceptorWithConfig creates server spans for gRPC unary requests using the
+// provider and propagator from cfg
+func GrpcUnaryTracingInterceptorWithConfig(cfg *ObservabilityMiddlewareConfig) grpc.UnaryServerInterceptor {
+	tracer := cfg.tracer("grpc-server")
+	propagator := cfg.propagator()
+
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		// Extract trace context from incoming metadata (W3C Trace Context)
+		ctx = extractIncoming(ctx, propagator)
+
+		// Create a span for this request
+		ctx, span := tracer.Start(ctx, grpcSpanName(info.FullMethod),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(grpcSpanAttributes(info.FullMethod)...),
+		)
+		defer span.End()
+
+		resp, err := handler(ctx, req)
+		finishGrpcServerSpan(span, err)
+
+		return resp, err
+	}
+}
+
+// GrpcStreamTracingInterceptor creates OpenTelemetry server spans for gRPC streaming requests
+func GrpcStreamTracingInterceptor() grpc.StreamServerInterceptor {
+	return GrpcStreamTracingInterceptorWithConfig(nil)
+}
+
+// GrpcStreamTracingInterceptorWithConfig creates server spans for gRPC streaming requests using
+// the provider and propagator from cfg
+func GrpcStreamTracingInterceptorWithConfig(cfg *ObservabilityMiddlewareConfig) grpc.StreamServerInterceptor {
+	tracer := cfg.tracer("grpc-server")
+	propagator := cfg.propagator()
+
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		// Extract trace context from incoming metadata (W3C Trace Context)
+		ctx := extractIncoming(stream.Context(), propagator)
+
+		// Create a span for this stream
+		ctx, span := tracer.Start(ctx, grpcSpanName(info.FullMethod),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(grpcSpanAttributes(info.FullMethod)...),
+		)
+		defer span.End()
+
+		err := handler(srv, &tracedServerStream{ServerStream: stream, ctx: ctx})
+		finishGrpcServerSpan(span, err)
+
+		return err
+	}
+}
@@ -0,0 +1,197 @@
+package observability
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// installTestTracing installs a span recorder and W3C propagator as globals for the test duration
+func installTestTracing(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	origTP := otel.GetTracerProvider()
+	origProp := otel.GetTextMapPropagator()
+
+	sr := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	t.Cleanup(func() {
+		otel.SetTracerProvider(origTP)
+		otel.SetTextMapPropagator(origProp)
+	})
+	return sr
+}
+
+func TestGrpcUnaryTracingInterceptor(t *testing.T) {
+	sr := installTestTracing(t)
+
+	parentTraceID := "4bf92f3577b34da6a3ce929d0e0e4736"
+	md := metadata.Pairs("traceparent", "00-"+parentTraceID+"-00f067aa0ba902b7-01")
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	var handlerSpan trace.SpanContext
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		handlerSpan = trace.SpanContextFromContext(ctx)
+		return nil, status.Error(codes.Internal, "boom")
+	}
+
+	interceptor := GrpcUnaryTracingInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.v1.Service/TestMethod"}
+
+	_, err := interceptor(ctx, &mockRequest{Message: "x"}, info, handler)
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("expected Internal error to be returned, got %v", err)
+	}
+
+	spans := sr.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	span := spans[0]
+
+	if span.Name() != "test.v1.Service/TestMethod" {
+		t.Errorf("unexpected span name %q", span.Name())
+	}
+	if span.SpanKind() != trace.SpanKindServer {
+		t.Errorf("expected server span, got %v", span.SpanKind())
+	}
+	if span.SpanContext().TraceID().String() != parentTraceID {
+		t.Errorf("expected trace id %s from metadata, got %s", parentTraceID, span.SpanContext().TraceID())
+	}
+	if handlerSpan.SpanID() != span.SpanContext().SpanID() {
+		t.Error("expected handler context to carry the server span")
+	}
+	if span.Status().Code != otelcodes.Error {
+		t.Errorf("expected error span status, got %v", span.Status().Code)
+	}
+
+	attrs := map[string]interface{}{}
+	for _, kv := range span.Attributes() {
+		attrs[string(kv.Key)] = kv.Value.AsInterface()
+	}
+	expected := map[string]interface{}{
+		"rpc.system":           "grpc",
+		"rpc.service":          "test.v1.Service",
+		"rpc.method":           "TestMethod",
+		"rpc.grpc.status_code": int64(codes.Internal),
+	}
+	for k, v := range expected {
+		if attrs[k] != v {
+			t.Errorf("expected attribute %s=%v, got %v", k, v, attrs[k])
+		}
+	}
+}
+
+func TestGrpcUnaryTracingInterceptor_ClientErrorLeavesStatusUnset(t *testing.T) {
+	sr := installTestTracing(t)
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "missing")
+	}
+
+	interceptor := GrpcUnaryTracingInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Get"}
+
+	_, _ = interceptor(context.Background(), &mockRequest{}, info, handler)
+
+	spans := sr.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	if spans[0].Status().Code != otelcodes.Unset {
+		t.Errorf("expected unset span status for NotFound, got %v", spans[0].Status().Code)
+	}
+}
+
+func TestGrpcStreamTracingInterceptor(t *testing.T) {
+	sr := installTestTracing(t)
+
+	var handlerSpan trace.SpanContext
+	handler := func(srv interface{}, stream grpc.ServerStream) error {
+		handlerSpan = trace.SpanContextFromContext(stream.Context())
+		return nil
+	}
+
+	interceptor := GrpcStreamTracingInterceptor()
+	info := &grpc.StreamServerInfo{FullMethod: "/test.Service/Watch", IsServerStream: true}
+	stream := &mockServerStream{ctx: context.Background()}
+
+	if err := interceptor(nil, stream, info, handler); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	spans := sr.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	if spans[0].SpanKind() != trace.SpanKindServer {
+		t.Errorf("expected server span, got %v", spans[0].SpanKind())
+	}
+	if handlerSpan.SpanID() != spans[0].SpanContext().SpanID() {
+		t.Error("expected stream context to carry the server span")
+	}
+	if spans[0].Status().Code != otelcodes.Unset {
+		t.Errorf("expected unset span status, got %v", spans[0].Status().Code)
+	}
+}
+
+func TestSplitGrpcMethod(t *testing.T) {
+	tests := []struct {
+		fullMethod string
+		service    string
+		method     string
+	}{
+		{"/pkg.Service/Method", "pkg.Service", "Method"},
+		{"pkg.Service/Method", "pkg.Service", "Method"},
+		{"/malformed", "malformed", ""},
+	}
+
+	for _, tt := range tests {
+		service, method := splitGrpcMethod(tt.fullMethod)
+		if service != tt.service || method != tt.method {
+			t.Errorf("splitGrpcMethod(%q) = (%q, %q), want (%q, %q)",
+				tt.fullMethod, service, method, tt.service, tt.method)
+		}
+	}
+}
+
+func TestGrpcTracingInterceptorsWithExplicitProvider(t *testing.T) {
+	globalSR := installTestTracing(t)
+
+	sr := tracetest.NewSpanRecorder()
+	cfg := &ObservabilityMiddlewareConfig{
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr)),
+		Propagator:     propagation.TraceContext{},
+	}
+
+	unary := GrpcUnaryTracingInterceptorWithConfig(cfg)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil }
+	if _, err := unary(context.Background(), &mockRequest{}, &grpc.UnaryServerInfo{FullMethod: "/test.Service/Unary"}, handler); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	stream := GrpcStreamTracingInterceptorWithConfig(cfg)
+	streamHandler := func(srv interface{}, ss grpc.ServerStream) error { return nil }
+	if err := stream(nil, &mockServerStream{ctx: context.Background()}, &grpc.StreamServerInfo{FullMethod: "/test.Service/Stream"}, streamHandler); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := len(sr.Ended()); got != 2 {
+		t.Errorf("expected 2 spans on the explicit provider, got %d", got)
+	}
+	if got := len(globalSR.Ended()); got != 0 {
+		t.Errorf("expected no spans on the global provider, got %d", got)
+	}
+}
@@ -0,0 +1,122 @@
+package observability
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// redactedValue replaces header values whenever headers are printed
+const redactedValue = "***"
+
+// Headers holds OTLP exporter headers parsed from the OTEL_EXPORTER_OTLP_HEADERS "k=v,k2=v2" format.
+// Values are redacted when the headers are formatted, logged or marshaled to JSON.
+type Headers map[string]string
+
+// SetValue parses a "k=v,k2=v2" string (cleanenv Setter interface)
+func (h *Headers) SetValue(s string) error {
+	parsed, err := ParseHeaders(s)
+	if err != nil {
+		return err
+	}
+	*h = parsed
+	return nil
+}
+
+// String returns the headers with values redacted
+func (h Headers) String() string {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, k+"="+redactedValue)
+	}
+	return strings.Join(pairs, ",")
+}
+
+// GoString returns the headers with values redacted for %#v formatting
+func (h Headers) GoString() string {
+	return "observability.Headers{" + h.String() + "}"
+}
+
+// MarshalJSON encodes the headers with values redacted
+func (h Headers) MarshalJSON() ([]byte, error) {
+	redacted := make(map[string]string, len(h))
+	for k := range h {
+		redacted[k] = redactedValue
+	}
+	return json.Marshal(redacted)
+}
+
+// Secret is a credential loaded from configuration, such as an auth token. It is redacted when
+// formatted, logged or marshaled to JSON; convert it to string to use the value.
+type Secret string
+
+// String returns the secret redacted, or "" if it is empty
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+	return redactedValue
+}
+
+// GoString returns the secret redacted for %#v formatting
+func (s Secret) GoString() string {
+	return fmt.Sprintf("observability.Secret(%q)", s.String())
+}
+
+// MarshalJSON encodes the secret redacted
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// ParseHeaders parses headers in the OTEL_EXPORTER_OTLP_HEADERS format: comma-separated
+// key=value pairs with percent-encoded values. Errors never include header values.
+func ParseHeaders(s string) (Headers, error) {
+	pairs, err := parseKeyValueList(s, "header")
+	if err != nil {
+		return nil, err
+	}
+	return Headers(pairs), nil
+}
+
+// parseKeyValueList parses the comma-separated, percent-encoded key=value format shared by
+// OTEL_EXPORTER_OTLP_HEADERS and OTEL_RESOURCE_ATTRIBUTES. kind names an entry in errors, which
+// never include values.
+func parseKeyValueList(s, kind string) (map[string]string, error) {
+	pairs := map[string]string{}
+	if strings.TrimSpace(s) == "" {
+		return pairs, nil
+	}
+
+	for i, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(entry, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid %s entry #%d: expected key=value", kind, i+1)
+		}
+
+		decodedKey, err := url.PathUnescape(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s entry #%d: key is not percent-encoded correctly", kind, i+1)
+		}
+		decodedValue, err := url.PathUnescape(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %s %q: not percent-encoded correctly", kind, decodedKey)
+		}
+		pairs[decodedKey] = decodedValue
+	}
+
+	return pairs, nil
+}
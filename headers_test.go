@@ -0,0 +1,209 @@
+package observability
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseHeaders(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Headers
+		wantErr bool
+	}{
+		{name: "Empty", input: "", want: Headers{}},
+		{name: "Single", input: "api-key=secret", want: Headers{"api-key": "secret"}},
+		{
+			name:  "Multiple With Spaces",
+			input: " api-key = secret , x-tenant=acme ",
+			want:  Headers{"api-key": "secret", "x-tenant": "acme"},
+		},
+		{name: "Percent Encoded Value", input: "authorization=Basic%20dXNlcjpwYXNz", want: Headers{"authorization": "Basic dXNlcjpwYXNz"}},
+		{name: "Value Containing Equals", input: "token=abc==", want: Headers{"token": "abc=="}},
+		{name: "Missing Equals", input: "api-key", wantErr: true},
+		{name: "Empty Key", input: "=secret", wantErr: true},
+		{name: "Bad Encoding", input: "api-key=%zz", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseHeaders(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error for %q", tt.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %d headers, got %d", len(tt.want), len(got))
+			}
+			for k, v := range tt.want {
+				if got[k] != v {
+					t.Errorf("expected header %s=%s, got %s", k, v, got[k])
+				}
+			}
+		})
+	}
+}
+
+func TestParseHeadersErrorDoesNotLeakValue(t *testing.T) {
+	_, err := ParseHeaders("api-key=super%zzsecret")
+	if err == nil {
+		t.Fatal("expected error for invalid encoding")
+	}
+	if strings.Contains(err.Error(), "secret") {
+		t.Errorf("error leaks header value: %v", err)
+	}
+}
+
+func TestHeadersRedaction(t *testing.T) {
+	cfg := BaseConfig{
+		ServiceName: "redaction-test",
+		OtelHeaders: Headers{"api-key": "super-secret"},
+	}
+
+	outputs := map[string]string{
+		"%v":  fmt.Sprintf("%v", cfg),
+		"%+v": fmt.Sprintf("%+v", cfg),
+		"%#v": fmt.Sprintf("%#v", cfg),
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+	outputs["json"] = string(data)
+
+	for format, out := range outputs {
+		if strings.Contains(out, "super-secret") {
+			t.Errorf("%s output leaks header value: %s", format, out)
+		}
+		if !strings.Contains(out, "api-key") {
+			t.Errorf("%s output should still show header key: %s", format, out)
+		}
+	}
+}
+
+func TestSecretRedaction(t *testing.T) {
+	cfg := BaseConfig{ServiceName: "redaction-test", DebugAuthToken: "super-secret"}
+
+	outputs := map[string]string{
+		"%v":  fmt.Sprintf("%v", cfg),
+		"%+v": fmt.Sprintf("%+v", cfg),
+		"%#v": fmt.Sprintf("%#v", cfg),
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+	outputs["json"] = string(data)
+
+	for format, out := range outputs {
+		if strings.Contains(out, "super-secret") {
+			t.Errorf("%s output leaks the secret: %s", format, out)
+		}
+	}
+	if Secret("").String() != "" {
+		t.Error("expected an empty secret to format as empty")
+	}
+}
+
+func TestLoadCfgExporterHeaders(t *testing.T) {
+	defer func() {
+		_ = os.Unsetenv("METRICS_HEADERS")
+		_ = os.Unsetenv("OTEL_COMPRESSION")
+	}()
+
+	_ = os.Unsetenv("LOG_LEVEL")
+	_ = os.Setenv("SERVICE_NAME", "headers-service")
+	_ = os.Setenv("METRICS_MODE", "pull")
+
+	t.Run("Parses Headers and Compression", func(t *testing.T) {
+		_ = os.Setenv("METRICS_HEADERS", "api-key=secret,x-tenant=acme")
+		_ = os.Setenv("OTEL_COMPRESSION", "gzip")
+
+		var cfg BaseConfig
+		if err := LoadCfg(&cfg); err != nil {
+			t.Fatalf("LoadCfg failed: %v", err)
+		}
+		if cfg.MetricsHeaders["api-key"] != "secret" || cfg.MetricsHeaders["x-tenant"] != "acme" {
+			t.Errorf("unexpected MetricsHeaders: %v", map[string]string(cfg.MetricsHeaders))
+		}
+		if cfg.OtelCompression != "gzip" {
+			t.Errorf("expected OtelCompression 'gzip', got '%s'", cfg.OtelCompression)
+		}
+		if cfg.MetricsCompression != "none" {
+			t.Errorf("expected default MetricsCompression 'none', got '%s'", cfg.MetricsCompression)
+		}
+		if cfg.OtelTimeout != 10 {
+			t.Errorf("expected default OtelTimeout 10, got %d", cfg.OtelTimeout)
+		}
+	})
+
+	t.Run("Invalid Headers Error Is Redacted", func(t *testing.T) {
+		_ = os.Setenv("METRICS_HEADERS", "api-key=top%zzsecret")
+		_ = os.Unsetenv("OTEL_COMPRESSION")
+
+		var cfg BaseConfig
+		err := LoadCfg(&cfg)
+		if err == nil {
+			t.Fatal("expected LoadCfg to fail for malformed METRICS_HEADERS")
+		}
+		if strings.Contains(err.Error(), "secret") {
+			t.Errorf("error leaks header value: %v", err)
+		}
+	})
+
+	t.Run("Invalid Compression", func(t *testing.T) {
+		_ = os.Unsetenv("METRICS_HEADERS")
+		_ = os.Setenv("OTEL_COMPRESSION", "zstd")
+
+		var cfg BaseConfig
+		if err := LoadCfg(&cfg); err == nil {
+			t.Error("expected LoadCfg to fail for invalid OTEL_COMPRESSION")
+		}
+	})
+}
+
+func TestInitOtelWithHeadersAndCompression(t *testing.T) {
+	for _, protocol := range []string{"http", "grpc"} {
+		t.Run(protocol, func(t *testing.T) {
+			cfg := BaseConfig{
+				ServiceName:           "test-otel-headers",
+				Version:               "1.0.0",
+				OtelEndpoint:          "localhost:4318",
+				TracesProtocol:        protocol,
+				OtelTracingSampleRate: 1.0,
+				OtelInsecure:          true,
+				OtelHeaders:           Headers{"api-key": "secret"},
+				OtelCompression:       "gzip",
+				OtelTimeout:           5,
+				MetricsMode:           "push",
+				MetricsPushEndpoint:   "localhost:4318",
+				MetricsPushInterval:   30,
+				MetricsProtocol:       protocol,
+				MetricsInsecure:       true,
+				MetricsHeaders:        Headers{"api-key": "secret"},
+				MetricsCompression:    "gzip",
+				MetricsTimeout:        5,
+			}
+
+			shutdown, err := InitOtel(cfg)
+			if err != nil {
+				t.Fatalf("InitOtel with headers failed: %v", err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			_ = shutdown(ctx) // Ignore error as collector may not be running
+		})
+	}
+}
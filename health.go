@@ -0,0 +1,317 @@
+package observability
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Paths of the health endpoints served on the metrics server
+const (
+	LivenessPath  = "/livez"
+	ReadinessPath = "/readyz"
+	HealthPath    = "/healthz"
+)
+
+// defaultHealthCheckTimeout bounds a check registered without a timeout
+const defaultHealthCheckTimeout = 5 * time.Second
+
+// HealthCheckFunc returns an error when the checked component is unhealthy
+type HealthCheckFunc func(ctx context.Context) error
+
+// HealthCheck is a named check run by the health endpoints
+type HealthCheck struct {
+	// Name identifies the check in responses and in the health.check.status gauge
+	Name string
+	// Check reports the component's health
+	Check HealthCheckFunc
+	// Timeout bounds a single run; the check fails once it expires (default 5s)
+	Timeout time.Duration
+	// Critical checks fail the endpoint; failures of other checks are only reported
+	Critical bool
+	// Liveness also runs the check on /livez. Keep it for checks that a restart can fix,
+	// such as a deadlocked worker, not for external dependencies.
+	Liveness bool
+}
+
+// HealthRegistry holds health checks and serves the liveness, readiness and health endpoints
+type HealthRegistry struct {
+	mu           sync.RWMutex
+	checks       map[string]HealthCheck
+	latest       map[string]healthCheckResult
+	shuttingDown atomic.Bool
+}
+
+// healthCheckResult is the JSON outcome of a single check
+type healthCheckResult struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Critical   bool   `json:"critical"`
+	DurationMs int64  `json:"duration_ms"`
+	Error      string `json:"error,omitempty"`
+}
+
+// healthResponse is the JSON body of the health endpoints
+type healthResponse struct {
+	Status       string              `json:"status"`
+	ShuttingDown bool                `json:"shutting_down,omitempty"`
+	Checks       []healthCheckResult `json:"checks"`
+}
+
+// Health statuses reported by the endpoints and checks
+const (
+	healthStatusOK   = "ok"
+	healthStatusFail = "fail"
+)
+
+// NewHealthRegistry returns an empty registry
+func NewHealthRegistry() *HealthRegistry {
+	return &HealthRegistry{
+		checks: make(map[string]HealthCheck),
+		latest: make(map[string]healthCheckResult),
+	}
+}
+
+// Register adds a check. Names must be unique.
+func (r *HealthRegistry) Register(check HealthCheck) error {
+	check.Name = strings.TrimSpace(check.Name)
+	if check.Name == "" {
+		return fmt.Errorf("health check name is required")
+	}
+	if check.Check == nil {
+		return fmt.Errorf("health check %q has no check function", check.Name)
+	}
+	if check.Timeout <= 0 {
+		check.Timeout = defaultHealthCheckTimeout
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.checks[check.Name]; ok {
+		return fmt.Errorf("health check %q already registered", check.Name)
+	}
+	r.checks[check.Name] = check
+	return nil
+}
+
+// Unregister removes the check named name, if any
+func (r *HealthRegistry) Unregister(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.checks, name)
+	delete(r.latest, name)
+}
+
+// SetShuttingDown makes readiness fail from now on so load balancers stop routing traffic.
+// Observability.Shutdown calls it first; call it earlier to drain before shutting down.
+func (r *HealthRegistry) SetShuttingDown() {
+	r.shuttingDown.Store(true)
+}
+
+// ShuttingDown reports whether SetShuttingDown was called
+func (r *HealthRegistry) ShuttingDown() bool {
+	return r.shuttingDown.Load()
+}
+
+// LivenessHandler serves /livez: the checks marked Liveness
+func (r *HealthRegistry) LivenessHandler() http.Handler {
+	return r.handler(true, false)
+}
+
+// ReadinessHandler serves /readyz: every check, failing once shutdown has begun
+func (r *HealthRegistry) ReadinessHandler() http.Handler {
+	return r.handler(false, true)
+}
+
+// HealthHandler serves /healthz: every check
+func (r *HealthRegistry) HealthHandler() http.Handler {
+	return r.handler(false, false)
+}
+
+// handler runs the selected checks and answers 200 or 503 with JSON details
+func (r *HealthRegistry) handler(livenessOnly, readiness bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		results := r.run(req.Context(), livenessOnly)
+
+		resp := healthResponse{Status: healthStatusOK, Checks: results}
+		for _, res := range results {
+			if res.Critical && res.Status == healthStatusFail {
+				resp.Status = healthStatusFail
+			}
+		}
+		if readiness && r.ShuttingDown() {
+			resp.Status = healthStatusFail
+			resp.ShuttingDown = true
+		}
+
+		code := http.StatusOK
+		if resp.Status == healthStatusFail {
+			code = http.StatusServiceUnavailable
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(code)
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+}
+
+// run executes the selected checks concurrently, each bounded by its timeout, sorted by name, and
+// keeps the results as the latest of each check unless ctx was canceled meanwhile
+func (r *HealthRegistry) run(ctx context.Context, livenessOnly bool) []healthCheckResult {
+	r.mu.RLock()
+	checks := make([]HealthCheck, 0, len(r.checks))
+	for _, c := range r.checks {
+		if !livenessOnly || c.Liveness {
+			checks = append(checks, c)
+		}
+	}
+	r.mu.RUnlock()
+	sort.Slice(checks, func(i, j int) bool { return checks[i].Name < checks[j].Name })
+
+	results := make([]healthCheckResult, len(checks))
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func(i int, c HealthCheck) {
+			defer wg.Done()
+			results[i] = runHealthCheck(ctx, c)
+		}(i, c)
+	}
+	wg.Wait()
+
+	if ctx.Err() == nil {
+		r.mu.Lock()
+		for _, res := range results {
+			if _, ok := r.checks[res.Name]; ok {
+				r.latest[res.Name] = res
+			}
+		}
+		r.mu.Unlock()
+	}
+	return results
+}
+
+// latestResults returns the last result of every registered check that has run, sorted by name
+func (r *HealthRegistry) latestResults() []healthCheckResult {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	results := make([]healthCheckResult, 0, len(r.latest))
+	for _, res := range r.latest {
+		results = append(results, res)
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].Name < results[j].Name })
+	return results
+}
+
+// startMonitor runs every check each interval so the latest results stay current without
+// probes. The returned function stops it and waits for a running round to be canceled.
+func (r *HealthRegistry) startMonitor(interval time.Duration) (stop func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			r.run(ctx, false)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// runHealthCheck runs one check, failing it when the timeout expires first or it panics
+func runHealthCheck(ctx context.Context, c HealthCheck) healthCheckResult {
+	ctx, cancel := context.WithTimeout(ctx, c.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				done <- fmt.Errorf("panic: %v", p)
+			}
+		}()
+		done <- c.Check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = fmt.Errorf("timed out after %s", c.Timeout)
+	}
+
+	res := healthCheckResult{
+		Name:       c.Name,
+		Status:     healthStatusOK,
+		Critical:   c.Critical,
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		res.Status = healthStatusFail
+		res.Error = err.Error()
+	}
+	return res
+}
+
+// registerMetrics reports the latest result of every check as a health.check.status gauge
+// (1 healthy, 0 failing). Collections never run checks, so a slow check cannot stall a scrape;
+// results come from the health endpoints and the monitor.
+func (r *HealthRegistry) registerMetrics(mp metric.MeterProvider) error {
+	meter := mp.Meter("health")
+	status, err := meter.Int64ObservableGauge("health.check.status",
+		metric.WithDescription("Result of each health check: 1 when healthy, 0 when failing"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create health check gauge: %w", err)
+	}
+
+	_, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		for _, res := range r.latestResults() {
+			value := int64(0)
+			if res.Status == healthStatusOK {
+				value = 1
+			}
+			o.ObserveInt64(status, value, metric.WithAttributes(
+				attribute.String("check", res.Name),
+				attribute.Bool("critical", res.Critical),
+			))
+		}
+		return nil
+	}, status)
+	if err != nil {
+		return fmt.Errorf("failed to register health check callback: %w", err)
+	}
+	return nil
+}
+
+// defaultHealth is the registry returned by GetHealthRegistry
+var defaultHealth atomic.Pointer[HealthRegistry]
+
+func init() {
+	defaultHealth.Store(NewHealthRegistry())
+}
+
+// GetHealthRegistry returns the process-wide registry. InitOtel and NewObservability serve it
+// unless globals are disabled, so checks can be registered before telemetry is initialized.
+func GetHealthRegistry() *HealthRegistry {
+	return defaultHealth.Load()
+}
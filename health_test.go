@@ -0,0 +1,288 @@
+package observability
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// serveHealth calls h and decodes its JSON response
+func serveHealth(t *testing.T, h http.Handler) (int, healthResponse) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	var resp healthResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid JSON response %q: %v", rec.Body.String(), err)
+	}
+	return rec.Code, resp
+}
+
+func TestHealthRegistryRegister(t *testing.T) {
+	ok := func(context.Context) error { return nil }
+	r := NewHealthRegistry()
+	if err := r.Register(HealthCheck{Name: "db", Check: ok}); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		check HealthCheck
+	}{
+		{name: "Empty Name", check: HealthCheck{Name: " ", Check: ok}},
+		{name: "Missing Function", check: HealthCheck{Name: "cache"}},
+		{name: "Duplicate", check: HealthCheck{Name: "db", Check: ok}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := r.Register(tt.check); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+
+	r.Unregister("db")
+	if err := r.Register(HealthCheck{Name: "db", Check: ok}); err != nil {
+		t.Errorf("expected to register again after Unregister, got %v", err)
+	}
+}
+
+func TestHealthRegistryHandlers(t *testing.T) {
+	r := NewHealthRegistry()
+	_ = r.Register(HealthCheck{Name: "worker", Liveness: true, Critical: true, Check: func(context.Context) error { return nil }})
+	_ = r.Register(HealthCheck{Name: "cache", Check: func(context.Context) error { return errors.New("connection refused") }})
+
+	code, resp := serveHealth(t, r.LivenessHandler())
+	if code != http.StatusOK || len(resp.Checks) != 1 || resp.Checks[0].Name != "worker" {
+		t.Errorf("expected liveness to run only the worker check, got %d %+v", code, resp)
+	}
+
+	// A failing non-critical check is reported without failing the endpoint
+	code, resp = serveHealth(t, r.HealthHandler())
+	if code != http.StatusOK || resp.Status != healthStatusOK || len(resp.Checks) != 2 {
+		t.Fatalf("expected a healthy response with two checks, got %d %+v", code, resp)
+	}
+	if resp.Checks[0].Name != "cache" || resp.Checks[0].Status != healthStatusFail || resp.Checks[0].Error != "connection refused" {
+		t.Errorf("expected the cache failure in the details, got %+v", resp.Checks[0])
+	}
+
+	_ = r.Register(HealthCheck{Name: "db", Critical: true, Check: func(context.Context) error { return errors.New("down") }})
+	if code, resp = serveHealth(t, r.ReadinessHandler()); code != http.StatusServiceUnavailable || resp.Status != healthStatusFail {
+		t.Errorf("expected a failing critical check to fail readiness, got %d %+v", code, resp)
+	}
+}
+
+func TestHealthRegistryShuttingDown(t *testing.T) {
+	r := NewHealthRegistry()
+	_ = r.Register(HealthCheck{Name: "worker", Liveness: true, Check: func(context.Context) error { return nil }})
+	if code, _ := serveHealth(t, r.ReadinessHandler()); code != http.StatusOK {
+		t.Fatalf("expected readiness to pass before shutdown, got %d", code)
+	}
+
+	r.SetShuttingDown()
+	if code, resp := serveHealth(t, r.ReadinessHandler()); code != http.StatusServiceUnavailable || !resp.ShuttingDown {
+		t.Errorf("expected readiness to fail once shutting down, got %d %+v", code, resp)
+	}
+	if code, _ := serveHealth(t, r.LivenessHandler()); code != http.StatusOK {
+		t.Errorf("expected liveness to keep passing while shutting down, got %d", code)
+	}
+}
+
+func TestHealthRegistryMetrics(t *testing.T) {
+	var runs atomic.Int32
+	r := NewHealthRegistry()
+	_ = r.Register(HealthCheck{Name: "db", Critical: true, Check: func(context.Context) error {
+		runs.Add(1)
+		return errors.New("down")
+	}})
+
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	defer func() { _ = mp.Shutdown(context.Background()) }()
+	if err := r.registerMetrics(mp); err != nil {
+		t.Fatalf("registerMetrics failed: %v", err)
+	}
+
+	collect := func() []metricdata.DataPoint[int64] {
+		var rm metricdata.ResourceMetrics
+		if err := reader.Collect(context.Background(), &rm); err != nil {
+			t.Fatalf("collect failed: %v", err)
+		}
+		for _, sm := range rm.ScopeMetrics {
+			for _, m := range sm.Metrics {
+				if gauge, ok := m.Data.(metricdata.Gauge[int64]); ok && m.Name == "health.check.status" {
+					return gauge.DataPoints
+				}
+			}
+		}
+		return nil
+	}
+
+	if points := collect(); len(points) != 0 || runs.Load() != 0 {
+		t.Fatalf("expected no result and no run before the check ran, got %+v after %d runs", points, runs.Load())
+	}
+	serveHealth(t, r.HealthHandler())
+	points := collect()
+	if len(points) != 1 || points[0].Value != 0 {
+		t.Errorf("expected the failing result of the last run, got %+v", points)
+	}
+	if runs.Load() != 1 {
+		t.Errorf("expected collections not to run the check, got %d runs", runs.Load())
+	}
+
+	r.Unregister("db")
+	if points := collect(); len(points) != 0 {
+		t.Errorf("expected no result for an unregistered check, got %+v", points)
+	}
+}
+
+func TestHealthRegistryMonitor(t *testing.T) {
+	var runs atomic.Int32
+	r := NewHealthRegistry()
+	_ = r.Register(HealthCheck{Name: "db", Check: func(context.Context) error {
+		runs.Add(1)
+		return nil
+	}})
+
+	stop := r.startMonitor(10 * time.Millisecond)
+	deadline := time.Now().Add(2 * time.Second)
+	for runs.Load() < 3 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	stop()
+
+	if runs.Load() < 3 {
+		t.Fatalf("expected the monitor to run the check repeatedly, got %d runs", runs.Load())
+	}
+	if results := r.latestResults(); len(results) != 1 || results[0].Status != healthStatusOK {
+		t.Errorf("expected the latest result of the check, got %+v", results)
+	}
+	after := runs.Load()
+	time.Sleep(30 * time.Millisecond)
+	if runs.Load() != after {
+		t.Error("expected no run after stop")
+	}
+}
+
+func TestRunHealthCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		check   HealthCheckFunc
+		wantErr string
+	}{
+		{name: "Healthy", check: func(context.Context) error { return nil }},
+		{name: "Timeout", check: func(ctx context.Context) error { <-ctx.Done(); time.Sleep(50 * time.Millisecond); return nil }, wantErr: "timed out after 20ms"},
+		{name: "Panic", check: func(context.Context) error { panic("boom") }, wantErr: "panic: boom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := runHealthCheck(context.Background(), HealthCheck{Name: tt.name, Check: tt.check, Timeout: 20 * time.Millisecond})
+			if res.Error != tt.wantErr {
+				t.Errorf("expected error %q, got %q", tt.wantErr, res.Error)
+			}
+			if (res.Status == healthStatusFail) != (tt.wantErr != "") {
+				t.Errorf("unexpected status %q", res.Status)
+			}
+		})
+	}
+}
+
+func TestNewObservability_HealthEndpoints(t *testing.T) {
+	o, err := NewObservability(BaseConfig{
+		ServiceName:        "test-otel-health",
+		Version:            "1.0.0",
+		TracingDisabled:    true,
+		MetricsMode:        "pull",
+		MetricsPath:        "/metrics",
+		MetricsPort:        0,
+		OtelDisableGlobals: true,
+	})
+	if err != nil {
+		t.Fatalf("NewObservability failed: %v", err)
+	}
+	defer func() { _ = o.Shutdown(context.Background()) }()
+
+	_ = o.Health.Register(HealthCheck{Name: "db", Critical: true, Check: func(context.Context) error { return nil }})
+	_ = o.Health.Register(HealthCheck{Name: "cache", Check: func(context.Context) error { return errors.New("down") }})
+
+	get := func(path string) (int, string) {
+		resp, err := http.Get("http://" + o.MetricsAddr() + path)
+		if err != nil {
+			t.Fatalf("failed to get %s: %v", path, err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(body)
+	}
+
+	for _, path := range []string{LivenessPath, ReadinessPath, HealthPath} {
+		if code, body := get(path); code != http.StatusOK || !strings.Contains(body, `"status":"ok"`) {
+			t.Errorf("expected %s to pass, got %d %s", path, code, body)
+		}
+	}
+
+	_, body := get("/metrics")
+	for _, series := range []string{
+		`health_check_status{check="db",critical="true"`,
+		`health_check_status{check="cache",critical="false"`,
+	} {
+		if !strings.Contains(body, series) {
+			t.Errorf("expected %s in the scrape, got:\n%s", series, body)
+		}
+	}
+
+	o.Health.SetShuttingDown()
+	if code, _ := get(ReadinessPath); code != http.StatusServiceUnavailable {
+		t.Errorf("expected readiness to fail once shutting down, got %d", code)
+	}
+}
+
+func TestLoadCfgHealthCheckInterval(t *testing.T) {
+	defer func() { _ = os.Unsetenv("HEALTH_CHECK_INTERVAL") }()
+
+	_ = os.Unsetenv("LOG_LEVEL")
+	_ = os.Setenv("SERVICE_NAME", "health-interval-service")
+	_ = os.Setenv("METRICS_MODE", "pull")
+
+	tests := []struct {
+		name     string
+		interval string
+		want     int
+		wantErr  bool
+	}{
+		{name: "Default", interval: "", want: 30},
+		{name: "Disabled", interval: "0", want: 0},
+		{name: "Negative", interval: "-1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_ = os.Unsetenv("HEALTH_CHECK_INTERVAL")
+			if tt.interval != "" {
+				_ = os.Setenv("HEALTH_CHECK_INTERVAL", tt.interval)
+			}
+
+			var cfg BaseConfig
+			err := LoadCfg(&cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadCfg() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && cfg.HealthCheckInterval != tt.want {
+				t.Errorf("expected an interval of %d, got %d", tt.want, cfg.HealthCheckInterval)
+			}
+		})
+	}
+}
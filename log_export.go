@@ -0,0 +1,298 @@
+package observability
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc/credentials"
+)
+
+// Log export targets accepted by LOGS_EXPORT
+const (
+	LogsExportNone = "none"
+	LogsExportOTLP = "otlp"
+)
+
+// contextFieldKey is the key of the field carrying a context.Context for the OTel core
+const contextFieldKey = "ctx"
+
+// isOtlpLogs returns true if log records are exported over OTLP
+func (b *BaseConfig) isOtlpLogs() bool {
+	return strings.ToLower(strings.TrimSpace(b.LogsExport)) == LogsExportOTLP
+}
+
+// contextField returns a field carrying ctx for the OTel core. JSON output skips it.
+func contextField(ctx context.Context) zap.Field {
+	return zap.Field{Key: contextFieldKey, Type: zapcore.SkipType, Interface: ctx}
+}
+
+// newLogExporter creates the OTLP log exporter. Logs share the trace exporter's collector settings
+// (TLS, headers, compression, timeout) and select the protocol with LogsProtocol. Without
+// LogsEndpoint, records go to the trace collector host with the default logs path.
+func newLogExporter(ctx context.Context, cfg BaseConfig) (sdklog.Exporter, error) {
+	protocol := strings.ToLower(strings.TrimSpace(cfg.LogsProtocol))
+	if protocol == "" {
+		protocol = "http"
+	}
+
+	tlsCfg, err := newTLSConfig(cfg.traceTLS())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load log exporter TLS config: %w", err)
+	}
+
+	ep := parseOtlpEndpoint(cfg.LogsEndpoint, cfg.OtelInsecure)
+	if strings.TrimSpace(cfg.LogsEndpoint) == "" {
+		ep = parseOtlpEndpoint(cfg.OtelEndpoint, cfg.OtelInsecure)
+		ep.path = ""
+	}
+
+	switch protocol {
+	case "grpc":
+		grpcOpts := []otlploggrpc.Option{
+			otlploggrpc.WithEndpoint(ep.host),
+		}
+		if ep.insecure {
+			grpcOpts = append(grpcOpts, otlploggrpc.WithInsecure())
+		} else if tlsCfg != nil {
+			grpcOpts = append(grpcOpts, otlploggrpc.WithTLSCredentials(credentials.NewTLS(tlsCfg)))
+		}
+		if len(cfg.OtelHeaders) > 0 {
+			grpcOpts = append(grpcOpts, otlploggrpc.WithHeaders(cfg.OtelHeaders))
+		}
+		if isGzip(cfg.OtelCompression) {
+			grpcOpts = append(grpcOpts, otlploggrpc.WithCompressor("gzip"))
+		}
+		if cfg.OtelTimeout > 0 {
+			grpcOpts = append(grpcOpts, otlploggrpc.WithTimeout(time.Duration(cfg.OtelTimeout)*time.Second))
+		}
+		exp, err := otlploggrpc.New(ctx, grpcOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create OTLP gRPC log exporter: %w", err)
+		}
+		return exp, nil
+	case "http":
+		httpOpts := []otlploghttp.Option{
+			otlploghttp.WithEndpoint(ep.host),
+		}
+		if ep.path != "" {
+			httpOpts = append(httpOpts, otlploghttp.WithURLPath(ep.path))
+		}
+		if ep.insecure {
+			httpOpts = append(httpOpts, otlploghttp.WithInsecure())
+		} else if tlsCfg != nil {
+			httpOpts = append(httpOpts, otlploghttp.WithTLSClientConfig(tlsCfg))
+		}
+		if len(cfg.OtelHeaders) > 0 {
+			httpOpts = append(httpOpts, otlploghttp.WithHeaders(cfg.OtelHeaders))
+		}
+		if isGzip(cfg.OtelCompression) {
+			httpOpts = append(httpOpts, otlploghttp.WithCompression(otlploghttp.GzipCompression))
+		}
+		if cfg.OtelTimeout > 0 {
+			httpOpts = append(httpOpts, otlploghttp.WithTimeout(time.Duration(cfg.OtelTimeout)*time.Second))
+		}
+		exp, err := otlploghttp.New(ctx, httpOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create OTLP HTTP log exporter: %w", err)
+		}
+		return exp, nil
+	default:
+		return nil, fmt.Errorf("invalid OTEL_LOGS_PROTOCOL: %s", protocol)
+	}
+}
+
+// otelCore is a zapcore.Core emitting every entry as an OTel log record
+type otelCore struct {
+	zapcore.LevelEnabler
+	logger otellog.Logger
+	fields []zapcore.Field
+}
+
+// NewOtelCore returns a zap core sending log entries through the given OTel LoggerProvider.
+// Records carry the trace context of a context field (see Logger.WithContext) or of
+// "trace_id"/"span_id" fields, as written by the Gin and gRPC middleware.
+func NewOtelCore(provider otellog.LoggerProvider, enab zapcore.LevelEnabler) zapcore.Core {
+	return &otelCore{
+		LevelEnabler: enab,
+		logger:       provider.Logger("logger"),
+	}
+}
+
+// With returns a core carrying additional fields
+func (c *otelCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := *c
+	clone.fields = make([]zapcore.Field, 0, len(c.fields)+len(fields))
+	clone.fields = append(clone.fields, c.fields...)
+	clone.fields = append(clone.fields, fields...)
+	return &clone
+}
+
+// Check adds the core to the checked entry if the level is enabled
+func (c *otelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write converts the entry and its fields into an OTel log record and emits it
+func (c *otelCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	all := make([]zapcore.Field, 0, len(c.fields)+len(fields))
+	all = append(all, c.fields...)
+	all = append(all, fields...)
+
+	ctx := context.Background()
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range all {
+		if f.Type == zapcore.SkipType {
+			if fieldCtx, ok := f.Interface.(context.Context); ok && fieldCtx != nil {
+				ctx = fieldCtx
+			}
+			continue
+		}
+		f.AddTo(enc)
+	}
+	ctx = withFieldSpanContext(ctx, enc.Fields)
+
+	var record otellog.Record
+	record.SetTimestamp(ent.Time)
+	record.SetObservedTimestamp(time.Now())
+	record.SetSeverity(otelSeverity(ent.Level))
+	record.SetSeverityText(ent.Level.CapitalString())
+	record.SetBody(otellog.StringValue(ent.Message))
+
+	attrs := make([]otellog.KeyValue, 0, len(enc.Fields)+3)
+	for k, v := range enc.Fields {
+		attrs = append(attrs, otellog.KeyValue{Key: k, Value: otelLogValue(v)})
+	}
+	if ent.Caller.Defined {
+		attrs = append(attrs,
+			otellog.String("code.filepath", ent.Caller.File),
+			otellog.Int("code.lineno", ent.Caller.Line),
+			otellog.String("code.function", ent.Caller.Function),
+		)
+	}
+	record.AddAttributes(attrs...)
+
+	c.logger.Emit(ctx, record)
+	return nil
+}
+
+// Sync is a no-op; records are flushed by the LoggerProvider
+func (c *otelCore) Sync() error {
+	return nil
+}
+
+// withFieldSpanContext adds the span context described by "trace_id"/"span_id" fields to ctx,
+// unless ctx already carries a valid span context. The fields do not say whether the trace was
+// sampled, so no trace flags are set; Logger.WithContext keeps the actual flags.
+func withFieldSpanContext(ctx context.Context, fields map[string]interface{}) context.Context {
+	if trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx
+	}
+	traceIDStr, _ := fields["trace_id"].(string)
+	spanIDStr, _ := fields["span_id"].(string)
+	traceID, err := trace.TraceIDFromHex(traceIDStr)
+	if err != nil {
+		return ctx
+	}
+	spanID, err := trace.SpanIDFromHex(spanIDStr)
+	if err != nil {
+		return ctx
+	}
+	return trace.ContextWithSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  spanID,
+	}))
+}
+
+// otelSeverity maps zap levels to OTel log severities
+func otelSeverity(level zapcore.Level) otellog.Severity {
+	switch level {
+	case zapcore.DebugLevel:
+		return otellog.SeverityDebug
+	case zapcore.InfoLevel:
+		return otellog.SeverityInfo
+	case zapcore.WarnLevel:
+		return otellog.SeverityWarn
+	case zapcore.ErrorLevel:
+		return otellog.SeverityError
+	case zapcore.DPanicLevel:
+		return otellog.SeverityFatal1
+	case zapcore.PanicLevel:
+		return otellog.SeverityFatal2
+	case zapcore.FatalLevel:
+		return otellog.SeverityFatal3
+	default:
+		return otellog.SeverityUndefined
+	}
+}
+
+// otelLogValue converts a value produced by zapcore.MapObjectEncoder into an OTel log value
+func otelLogValue(v interface{}) otellog.Value {
+	switch val := v.(type) {
+	case nil:
+		return otellog.Value{}
+	case string:
+		return otellog.StringValue(val)
+	case bool:
+		return otellog.BoolValue(val)
+	case int:
+		return otellog.IntValue(val)
+	case int8:
+		return otellog.Int64Value(int64(val))
+	case int16:
+		return otellog.Int64Value(int64(val))
+	case int32:
+		return otellog.Int64Value(int64(val))
+	case int64:
+		return otellog.Int64Value(val)
+	case uint8:
+		return otellog.Int64Value(int64(val))
+	case uint16:
+		return otellog.Int64Value(int64(val))
+	case uint32:
+		return otellog.Int64Value(int64(val))
+	case uint64:
+		if val > math.MaxInt64 {
+			return otellog.StringValue(fmt.Sprint(val))
+		}
+		return otellog.Int64Value(int64(val))
+	case uint:
+		return otellog.Int64Value(int64(val))
+	case float32:
+		return otellog.Float64Value(float64(val))
+	case float64:
+		return otellog.Float64Value(val)
+	case []byte:
+		return otellog.BytesValue(val)
+	case time.Time:
+		return otellog.StringValue(val.Format(time.RFC3339Nano))
+	case time.Duration:
+		return otellog.StringValue(val.String())
+	case []interface{}:
+		values := make([]otellog.Value, 0, len(val))
+		for _, item := range val {
+			values = append(values, otelLogValue(item))
+		}
+		return otellog.SliceValue(values...)
+	case map[string]interface{}:
+		kvs := make([]otellog.KeyValue, 0, len(val))
+		for k, item := range val {
+			kvs = append(kvs, otellog.KeyValue{Key: k, Value: otelLogValue(item)})
+		}
+		return otellog.MapValue(kvs...)
+	default:
+		return otellog.StringValue(fmt.Sprint(val))
+	}
+}
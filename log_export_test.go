@@ -0,0 +1,225 @@
+package observability
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap/zapcore"
+)
+
+// recordingLogExporter keeps exported log records in memory
+type recordingLogExporter struct {
+	mu      sync.Mutex
+	records []sdklog.Record
+}
+
+func (e *recordingLogExporter) Export(ctx context.Context, records []sdklog.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, r := range records {
+		e.records = append(e.records, r.Clone())
+	}
+	return nil
+}
+
+func (e *recordingLogExporter) Shutdown(ctx context.Context) error   { return nil }
+func (e *recordingLogExporter) ForceFlush(ctx context.Context) error { return nil }
+
+func (e *recordingLogExporter) Records() []sdklog.Record {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]sdklog.Record(nil), e.records...)
+}
+
+// recordAttributes returns the attributes of a log record as a map
+func recordAttributes(r sdklog.Record) map[string]otellog.Value {
+	attrs := map[string]otellog.Value{}
+	r.WalkAttributes(func(kv otellog.KeyValue) bool {
+		attrs[kv.Key] = kv.Value
+		return true
+	})
+	return attrs
+}
+
+func TestOtelCore(t *testing.T) {
+	exp := &recordingLogExporter{}
+	provider := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exp)))
+	logger := newLogger(&BaseConfig{ServiceName: "otel-core-service", LogLevel: "info"}, provider)
+
+	t.Run("Severity Body And Attributes", func(t *testing.T) {
+		logger.Warn("cache miss", "key", "user:42", "attempt", 3, "hit", false)
+
+		records := exp.Records()
+		if len(records) != 1 {
+			t.Fatalf("expected 1 record, got %d", len(records))
+		}
+		r := records[0]
+		if r.Severity() != otellog.SeverityWarn || r.SeverityText() != "WARN" {
+			t.Errorf("unexpected severity %v/%q", r.Severity(), r.SeverityText())
+		}
+		if r.Body().AsString() != "cache miss" {
+			t.Errorf("unexpected body %q", r.Body().AsString())
+		}
+
+		attrs := recordAttributes(r)
+		if attrs["key"].AsString() != "user:42" || attrs["attempt"].AsInt64() != 3 || attrs["hit"].AsBool() {
+			t.Errorf("unexpected attributes: %v", attrs)
+		}
+		if attrs["service"].AsString() != "otel-core-service" {
+			t.Errorf("expected logger fields on the record, got %v", attrs)
+		}
+		if _, ok := attrs["code.lineno"]; !ok {
+			t.Error("expected caller attributes on the record")
+		}
+	})
+
+	t.Run("Below Level Is Dropped", func(t *testing.T) {
+		before := len(exp.Records())
+		logger.Debug("noisy")
+		if got := len(exp.Records()); got != before {
+			t.Errorf("expected debug record to be dropped at info level, got %d new", got-before)
+		}
+	})
+
+	t.Run("Trace Context From Context", func(t *testing.T) {
+		tp := sdktrace.NewTracerProvider()
+		ctx, span := tp.Tracer("otel-core-test").Start(context.Background(), "op")
+		defer span.End()
+
+		logger.WithContext(ctx).Info("in span")
+
+		records := exp.Records()
+		r := records[len(records)-1]
+		if r.TraceID() != span.SpanContext().TraceID() || r.SpanID() != span.SpanContext().SpanID() {
+			t.Errorf("expected record correlated with span %s, got trace %s span %s",
+				span.SpanContext().TraceID(), r.TraceID(), r.SpanID())
+		}
+		if r.TraceFlags() != span.SpanContext().TraceFlags() {
+			t.Errorf("expected the span's trace flags %s, got %s", span.SpanContext().TraceFlags(), r.TraceFlags())
+		}
+		if _, ok := recordAttributes(r)[contextFieldKey]; ok {
+			t.Error("expected context field not to be exported as an attribute")
+		}
+	})
+
+	t.Run("Trace Context From Fields", func(t *testing.T) {
+		logger.Info("HTTP Request",
+			"trace_id", "4bf92f3577b34da6a3ce929d0e0e4736",
+			"span_id", "00f067aa0ba902b7",
+		)
+
+		records := exp.Records()
+		r := records[len(records)-1]
+		if r.TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" || r.SpanID().String() != "00f067aa0ba902b7" {
+			t.Errorf("expected record correlated from trace_id/span_id fields, got trace %s span %s", r.TraceID(), r.SpanID())
+		}
+		if r.TraceFlags().IsSampled() {
+			t.Error("expected no sampled flag when only trace_id/span_id fields are known")
+		}
+	})
+}
+
+func TestOtelSeverity(t *testing.T) {
+	tests := []struct {
+		level zapcore.Level
+		want  otellog.Severity
+	}{
+		{zapcore.DebugLevel, otellog.SeverityDebug},
+		{zapcore.InfoLevel, otellog.SeverityInfo},
+		{zapcore.WarnLevel, otellog.SeverityWarn},
+		{zapcore.ErrorLevel, otellog.SeverityError},
+		{zapcore.DPanicLevel, otellog.SeverityFatal1},
+		{zapcore.PanicLevel, otellog.SeverityFatal2},
+		{zapcore.FatalLevel, otellog.SeverityFatal3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.level.String(), func(t *testing.T) {
+			if got := otelSeverity(tt.level); got != tt.want {
+				t.Errorf("otelSeverity(%v) = %v, want %v", tt.level, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewObservabilityWithOtlpLogs(t *testing.T) {
+	for _, protocol := range []string{"http", "grpc"} {
+		t.Run(protocol, func(t *testing.T) {
+			cfg := BaseConfig{
+				ServiceName:           "test-otel-logs",
+				Version:               "1.0.0",
+				LogLevel:              "info",
+				LogsExport:            "otlp",
+				LogsProtocol:          protocol,
+				OtelEndpoint:          "localhost:4318",
+				OtelInsecure:          true,
+				OtelTracingSampleRate: 1.0,
+				MetricsPort:           0,
+				MetricsMode:           "pull",
+				MetricsPath:           "/metrics",
+				OtelDisableGlobals:    true,
+			}
+
+			o, err := NewObservability(cfg)
+			if err != nil {
+				t.Fatalf("NewObservability with OTLP logs failed: %v", err)
+			}
+			if o.LoggerProvider == nil {
+				t.Fatal("expected a LoggerProvider when LOGS_EXPORT=otlp")
+			}
+			o.Logger.Info("exported over OTLP")
+
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			_ = o.Shutdown(ctx) // Ignore error as collector may not be running
+		})
+	}
+}
+
+func TestLoadCfgLogsExport(t *testing.T) {
+	defer func() {
+		_ = os.Unsetenv("LOGS_EXPORT")
+		_ = os.Unsetenv("OTEL_LOGS_PROTOCOL")
+	}()
+
+	_ = os.Unsetenv("LOG_LEVEL")
+	_ = os.Setenv("SERVICE_NAME", "logs-export-service")
+	_ = os.Setenv("METRICS_MODE", "pull")
+
+	tests := []struct {
+		name     string
+		export   string
+		protocol string
+		wantErr  bool
+	}{
+		{name: "Default None", export: "", protocol: "", wantErr: false},
+		{name: "OTLP gRPC", export: "otlp", protocol: "grpc", wantErr: false},
+		{name: "Invalid Export", export: "syslog", protocol: "", wantErr: true},
+		{name: "Invalid Protocol", export: "otlp", protocol: "udp", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_ = os.Unsetenv("LOGS_EXPORT")
+			_ = os.Unsetenv("OTEL_LOGS_PROTOCOL")
+			if tt.export != "" {
+				_ = os.Setenv("LOGS_EXPORT", tt.export)
+			}
+			if tt.protocol != "" {
+				_ = os.Setenv("OTEL_LOGS_PROTOCOL", tt.protocol)
+			}
+
+			var cfg BaseConfig
+			err := LoadCfg(&cfg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoadCfg() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
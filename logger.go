@@ -1,8 +1,12 @@
 package observability
 
 import (
+	"context"
 	"os"
 
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/global"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -13,7 +17,18 @@ type Logger struct {
 	*zap.SugaredLogger
 }
 
+// NewLogger creates the JSON stdout logger. With LOGS_EXPORT=otlp records are also sent through the
+// global OTel LoggerProvider, installed by InitOtel.
 func NewLogger(cfg *BaseConfig) *Logger {
+	var provider otellog.LoggerProvider
+	if cfg != nil && cfg.isOtlpLogs() {
+		provider = global.GetLoggerProvider()
+	}
+	return newLogger(cfg, provider)
+}
+
+// newLogger creates the logger, teeing records to provider when it is not nil
+func newLogger(cfg *BaseConfig, provider otellog.LoggerProvider) *Logger {
 	level := zapcore.InfoLevel
 	service := "unknown"
 	version := "unknown"
@@ -35,13 +50,35 @@ func NewLogger(cfg *BaseConfig) *Logger {
 		zapcore.AddSync(os.Stdout),
 		level,
 	)
+	if provider != nil {
+		core = zapcore.NewTee(core, NewOtelCore(provider, level))
+	}
 
 	l := zap.New(core, zap.AddCaller(), zap.AddStacktrace(zapcore.ErrorLevel))
 	l = l.With(zap.String("service", service), zap.String("version", version))
 
+	// Attach the same identity attributes as the OTel resource (service.* is already present)
+	if cfg != nil {
+		var fields []zap.Field
+		for _, kv := range resourceAttributes(cfg)[2:] {
+			fields = append(fields, zap.Any(string(kv.Key), kv.Value.AsInterface()))
+		}
+		l = l.With(fields...)
+	}
+
 	return &Logger{SugaredLogger: l.Sugar()}
 }
 
+// WithContext returns a logger adding the trace_id and span_id of the span in ctx. Exported OTel
+// log records are correlated with that span.
+func (l *Logger) WithContext(ctx context.Context) *Logger {
+	args := []interface{}{contextField(ctx)}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		args = append(args, "trace_id", sc.TraceID().String(), "span_id", sc.SpanID().String())
+	}
+	return &Logger{SugaredLogger: l.SugaredLogger.With(args...)}
+}
+
 // Helper methods for logging
 func (l *Logger) Info(msg string, args ...any)  { l.Infow(msg, args...) }
 func (l *Logger) Error(msg string, args ...any) { l.Errorw(msg, args...) }
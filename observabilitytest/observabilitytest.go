@@ -0,0 +1,282 @@
+// Package observabilitytest records spans, metrics and logs in memory for testing code
+// instrumented with go-observability, and provides assertion helpers over them.
+package observabilitytest
+
+import (
+	"context"
+	"testing"
+
+	observability "github.com/ecoma-io/go-observability"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// Recorder holds in-memory telemetry providers and what they recorded
+type Recorder struct {
+	// Spans records started and ended spans
+	Spans *tracetest.SpanRecorder
+	// Reader collects metrics on demand
+	Reader *sdkmetric.ManualReader
+	// Logs holds the entries written through Logger
+	Logs *observer.ObservedLogs
+	// Logger writes every level to Logs
+	Logger *observability.Logger
+	// TracerProvider records every span into Spans
+	TracerProvider *sdktrace.TracerProvider
+	// MeterProvider is read by Reader
+	MeterProvider *sdkmetric.MeterProvider
+	// Propagator is the W3C Trace Context and Baggage propagator
+	Propagator propagation.TextMapPropagator
+}
+
+// New creates a Recorder without touching the otel globals. Use MiddlewareConfig to wire it into
+// the *WithConfig middleware.
+func New(t testing.TB) *Recorder {
+	t.Helper()
+
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	core, logs := observer.New(zapcore.DebugLevel)
+
+	r := &Recorder{
+		Spans:  spans,
+		Reader: reader,
+		Logs:   logs,
+		Logger: &observability.Logger{SugaredLogger: zap.New(core, zap.AddCaller()).Sugar()},
+		TracerProvider: sdktrace.NewTracerProvider(
+			sdktrace.WithSampler(sdktrace.AlwaysSample()),
+			sdktrace.WithSpanProcessor(spans),
+		),
+		MeterProvider: sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+		Propagator: propagation.NewCompositeTextMapPropagator(
+			propagation.TraceContext{},
+			propagation.Baggage{},
+		),
+	}
+
+	t.Cleanup(func() {
+		_ = r.TracerProvider.Shutdown(context.Background())
+		_ = r.MeterProvider.Shutdown(context.Background())
+	})
+	return r
+}
+
+// Install creates a Recorder and installs its providers and propagator as otel globals. The
+// previous globals are restored when the test ends.
+func Install(t testing.TB) *Recorder {
+	t.Helper()
+
+	origTP := otel.GetTracerProvider()
+	origMP := otel.GetMeterProvider()
+	origProp := otel.GetTextMapPropagator()
+
+	r := New(t)
+	otel.SetTracerProvider(r.TracerProvider)
+	otel.SetMeterProvider(r.MeterProvider)
+	otel.SetTextMapPropagator(r.Propagator)
+
+	t.Cleanup(func() {
+		otel.SetTracerProvider(origTP)
+		otel.SetMeterProvider(origMP)
+		otel.SetTextMapPropagator(origProp)
+	})
+	return r
+}
+
+// MiddlewareConfig returns a middleware configuration bound to the recorder's providers
+func (r *Recorder) MiddlewareConfig() *observability.ObservabilityMiddlewareConfig {
+	return &observability.ObservabilityMiddlewareConfig{
+		TracerProvider: r.TracerProvider,
+		MeterProvider:  r.MeterProvider,
+		Propagator:     r.Propagator,
+	}
+}
+
+// EndedSpans returns the ended spans named name, or all ended spans if name is ""
+func (r *Recorder) EndedSpans(name string) []sdktrace.ReadOnlySpan {
+	var spans []sdktrace.ReadOnlySpan
+	for _, s := range r.Spans.Ended() {
+		if name == "" || s.Name() == name {
+			spans = append(spans, s)
+		}
+	}
+	return spans
+}
+
+// AssertSpan checks that an ended span named name carries all attrs and returns the first match
+func (r *Recorder) AssertSpan(t testing.TB, name string, attrs ...attribute.KeyValue) sdktrace.ReadOnlySpan {
+	t.Helper()
+
+	candidates := r.EndedSpans(name)
+	for _, s := range candidates {
+		if hasAttributes(s.Attributes(), attrs) {
+			return s
+		}
+	}
+	if len(candidates) == 0 {
+		t.Errorf("no ended span named %q (ended spans: %v)", name, spanNames(r.Spans.Ended()))
+	} else {
+		t.Errorf("no ended span named %q with attributes %v (found %d without them)", name, attrs, len(candidates))
+	}
+	return nil
+}
+
+// Collect gathers the current metrics from the manual reader
+func (r *Recorder) Collect(t testing.TB) metricdata.ResourceMetrics {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	if err := r.Reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("failed to collect metrics: %v", err)
+	}
+	return rm
+}
+
+// CounterValue returns the value of a counter or up-down counter for exactly attrs, and whether
+// such a data point exists
+func (r *Recorder) CounterValue(t testing.TB, name string, attrs ...attribute.KeyValue) (float64, bool) {
+	t.Helper()
+
+	set := attribute.NewSet(attrs...)
+	m, ok := findMetric(r.Collect(t), name)
+	if !ok {
+		return 0, false
+	}
+	switch data := m.Data.(type) {
+	case metricdata.Sum[int64]:
+		for _, dp := range data.DataPoints {
+			if dp.Attributes.Equals(&set) {
+				return float64(dp.Value), true
+			}
+		}
+	case metricdata.Sum[float64]:
+		for _, dp := range data.DataPoints {
+			if dp.Attributes.Equals(&set) {
+				return dp.Value, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// AssertCounter checks that a counter has the value want for exactly attrs
+func (r *Recorder) AssertCounter(t testing.TB, name string, want float64, attrs ...attribute.KeyValue) {
+	t.Helper()
+
+	got, ok := r.CounterValue(t, name, attrs...)
+	if !ok {
+		t.Errorf("no data point for counter %q with attributes %v", name, attrs)
+		return
+	}
+	if got != want {
+		t.Errorf("counter %q with attributes %v = %v, want %v", name, attrs, got, want)
+	}
+}
+
+// AssertHistogramCount checks that a histogram recorded want measurements for exactly attrs
+func (r *Recorder) AssertHistogramCount(t testing.TB, name string, want uint64, attrs ...attribute.KeyValue) {
+	t.Helper()
+
+	set := attribute.NewSet(attrs...)
+	m, ok := findMetric(r.Collect(t), name)
+	if !ok {
+		t.Errorf("no histogram named %q", name)
+		return
+	}
+
+	var got uint64
+	found := false
+	switch data := m.Data.(type) {
+	case metricdata.Histogram[int64]:
+		for _, dp := range data.DataPoints {
+			if dp.Attributes.Equals(&set) {
+				got, found = dp.Count, true
+			}
+		}
+	case metricdata.Histogram[float64]:
+		for _, dp := range data.DataPoints {
+			if dp.Attributes.Equals(&set) {
+				got, found = dp.Count, true
+			}
+		}
+	}
+	if !found {
+		t.Errorf("no data point for histogram %q with attributes %v", name, attrs)
+		return
+	}
+	if got != want {
+		t.Errorf("histogram %q with attributes %v has %d measurements, want %d", name, attrs, got, want)
+	}
+}
+
+// AssertLog checks that an entry at level with message msg was logged and returns the first match
+func (r *Recorder) AssertLog(t testing.TB, level zapcore.Level, msg string) *observer.LoggedEntry {
+	t.Helper()
+
+	for _, e := range r.Logs.FilterLevelExact(level).FilterMessage(msg).All() {
+		entry := e
+		return &entry
+	}
+	t.Errorf("no %s log with message %q", level, msg)
+	return nil
+}
+
+// AssertLogWithSpan checks that an entry at level carries the trace_id of span (a trace.Span or
+// sdktrace.ReadOnlySpan) and returns the first match
+func (r *Recorder) AssertLogWithSpan(t testing.TB, level zapcore.Level, span interface{ SpanContext() trace.SpanContext }) *observer.LoggedEntry {
+	t.Helper()
+
+	if span == nil {
+		t.Errorf("no span given to match %s logs against", level)
+		return nil
+	}
+	traceID := span.SpanContext().TraceID().String()
+	for _, e := range r.Logs.FilterLevelExact(level).FilterField(zap.String("trace_id", traceID)).All() {
+		entry := e
+		return &entry
+	}
+	t.Errorf("no %s log with trace_id %s", level, traceID)
+	return nil
+}
+
+// findMetric returns the metric named name from any scope
+func findMetric(rm metricdata.ResourceMetrics, name string) (metricdata.Metrics, bool) {
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name == name {
+				return m, true
+			}
+		}
+	}
+	return metricdata.Metrics{}, false
+}
+
+// hasAttributes reports whether got contains every attribute in want
+func hasAttributes(got, want []attribute.KeyValue) bool {
+	set := attribute.NewSet(got...)
+	for _, kv := range want {
+		v, ok := set.Value(kv.Key)
+		if !ok || v != kv.Value {
+			return false
+		}
+	}
+	return true
+}
+
+// spanNames lists the names of spans for failure messages
+func spanNames(spans []sdktrace.ReadOnlySpan) []string {
+	names := make([]string, 0, len(spans))
+	for _, s := range spans {
+		names = append(names, s.Name())
+	}
+	return names
+}
@@ -0,0 +1,131 @@
+package observabilitytest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	observability "github.com/ecoma-io/go-observability"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// recordingTB captures assertion failures instead of failing the test
+type recordingTB struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Errorf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func TestInstallWithGinMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rec := Install(t)
+
+	if otel.GetTracerProvider() != rec.TracerProvider {
+		t.Fatal("expected Install to set the global TracerProvider")
+	}
+
+	router := gin.New()
+	router.Use(observability.GinMiddleware(rec.Logger, "orders")...)
+	router.GET("/orders", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/orders", nil))
+
+	span := rec.AssertSpan(t, "GET /orders")
+	rec.AssertLog(t, zapcore.InfoLevel, "HTTP Request")
+	rec.AssertLogWithSpan(t, zapcore.InfoLevel, span)
+}
+
+func TestNewWithGrpcClientInterceptor(t *testing.T) {
+	origTP := otel.GetTracerProvider()
+	rec := New(t)
+	if otel.GetTracerProvider() != origTP {
+		t.Fatal("expected New to leave the globals untouched")
+	}
+
+	interceptor := observability.GrpcUnaryClientInterceptorWithConfig(rec.Logger, rec.MiddlewareConfig())
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return nil
+	}
+	if err := interceptor(context.Background(), "/orders.v1.Orders/Get", nil, nil, nil, invoker); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	span := rec.AssertSpan(t, "orders.v1.Orders/Get",
+		attribute.String("rpc.service", "orders.v1.Orders"),
+		attribute.String("rpc.method", "Get"),
+	)
+	rec.AssertHistogramCount(t, "rpc.client.duration", 1,
+		attribute.String("rpc.system", "grpc"),
+		attribute.String("rpc.service", "orders.v1.Orders"),
+		attribute.String("rpc.method", "Get"),
+		attribute.Int("rpc.grpc.status_code", int(codes.OK)),
+	)
+	rec.AssertLogWithSpan(t, zapcore.InfoLevel, span)
+}
+
+func TestAssertCounter(t *testing.T) {
+	rec := New(t)
+
+	counter, err := rec.MeterProvider.Meter("orders").Int64Counter("orders_total")
+	if err != nil {
+		t.Fatalf("failed to create counter: %v", err)
+	}
+	ctx := context.Background()
+	counter.Add(ctx, 2, metricAttrs("status", "paid"))
+	counter.Add(ctx, 1, metricAttrs("status", "paid"))
+	counter.Add(ctx, 5, metricAttrs("status", "failed"))
+
+	rec.AssertCounter(t, "orders_total", 3, attribute.String("status", "paid"))
+	rec.AssertCounter(t, "orders_total", 5, attribute.String("status", "failed"))
+}
+
+func TestAssertionFailures(t *testing.T) {
+	rec := New(t)
+	_, span := rec.TracerProvider.Tracer("test").Start(context.Background(), "op")
+	span.End()
+
+	tests := []struct {
+		name   string
+		assert func(tb testing.TB)
+	}{
+		{name: "Missing Span", assert: func(tb testing.TB) { rec.AssertSpan(tb, "other") }},
+		{name: "Missing Attribute", assert: func(tb testing.TB) { rec.AssertSpan(tb, "op", attribute.Bool("error", true)) }},
+		{name: "Missing Counter", assert: func(tb testing.TB) { rec.AssertCounter(tb, "missing_total", 1) }},
+		{name: "Missing Histogram", assert: func(tb testing.TB) { rec.AssertHistogramCount(tb, "missing.duration", 1) }},
+		{name: "Missing Log", assert: func(tb testing.TB) { rec.AssertLog(tb, zapcore.ErrorLevel, "boom") }},
+		{name: "Missing Log For Span", assert: func(tb testing.TB) { rec.AssertLogWithSpan(tb, zapcore.InfoLevel, span) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tb := &recordingTB{TB: t}
+			tt.assert(tb)
+			if len(tb.failures) != 1 {
+				t.Errorf("expected 1 assertion failure, got %v", tb.failures)
+			}
+		})
+	}
+}
+
+// metricAttrs builds an AddOption from key/value string pairs
+func metricAttrs(kv ...string) metric.AddOption {
+	attrs := make([]attribute.KeyValue, 0, len(kv)/2)
+	for i := 0; i+1 < len(kv); i += 2 {
+		attrs = append(attrs, attribute.String(kv[i], kv[i+1]))
+	}
+	return metric.WithAttributes(attrs...)
+}
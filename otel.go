@@ -2,244 +2,751 @@ package observability
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
+	prom "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
 	"go.opentelemetry.io/otel/exporters/prometheus"
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/global"
 	"go.opentelemetry.io/otel/metric"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
 	"go.opentelemetry.io/otel/propagation"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
-	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
 	"go.opentelemetry.io/otel/trace"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+	"google.golang.org/grpc/credentials"
+	_ "google.golang.org/grpc/encoding/gzip" // registers the gzip compressor for OTLP gRPC exporters
 )
 
+// Observability holds the telemetry providers created from a BaseConfig
+type Observability struct {
+	// TracerProvider is the SDK tracer provider exporting spans (nil when TRACING_DISABLED=true)
+	TracerProvider *sdktrace.TracerProvider
+	// MeterProvider is the SDK meter provider with the configured readers (nil when METRICS_MODE=none)
+	MeterProvider *sdkmetric.MeterProvider
+	// Registry is the Prometheus registry of the OTel metrics served at MetricsPath (nil without
+	// pull mode). In global mode the default Prometheus registry is served alongside it.
+	Registry *prom.Registry
+	// LoggerProvider exports log records over OTLP (nil unless LOGS_EXPORT=otlp)
+	LoggerProvider *sdklog.LoggerProvider
+	// Propagator is the W3C Trace Context and Baggage propagator
+	Propagator propagation.TextMapPropagator
+	// Logger is the service logger carrying the same identity as the resource
+	Logger *Logger
+	// Health holds the health checks served at /livez, /readyz and /healthz
+	Health *HealthRegistry
+
+	metricsServer   *http.Server
+	metricsAddr     net.Addr
+	metricsListener net.Listener
+	metricsShutdown []func(context.Context) error
+	debugServer     *http.Server
+	debugAddr       net.Addr
+	debugListener   net.Listener
+
+	healthMonitorStop func()
+}
+
 // InitOtel initializes OpenTelemetry with support for Tracing (Push)
 // and Metrics (Pull/Push/Hybrid)
 func InitOtel(cfg BaseConfig) (func(context.Context) error, error) {
+	o, err := NewObservability(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if o.TracerProvider == nil && o.MeterProvider == nil && o.LoggerProvider == nil && o.debugServer == nil {
+		// Telemetry is disabled: nothing to flush or release
+		return func(context.Context) error { return nil }, nil
+	}
+	return o.Shutdown, nil
+}
+
+// NewObservability initializes OpenTelemetry like InitOtel and returns a handle to the created
+// providers, registry, logger and metrics server
+func NewObservability(cfg BaseConfig) (_ *Observability, err error) {
 	ctx := context.Background()
+	o := &Observability{}
+
+	// Release everything started so far when a later step fails, so the call can be retried
+	var traceExp sdktrace.SpanExporter
+	defer func() {
+		if err != nil {
+			o.closeOnError(traceExp)
+		}
+	}()
 
 	// 1. Initialize Resource identifying the service
 	res, err := resource.New(ctx,
-		resource.WithAttributes(
-			semconv.ServiceName(cfg.ServiceName),
-			semconv.ServiceVersion(cfg.Version),
-		),
+		resource.WithAttributes(resourceAttributes(&cfg)...),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create resource: %w", err)
 	}
 
-	// 2. Configure Tracing (Push model sending to Otel Collector)
-	// Build options for trace exporter, respecting insecure config
-	traceOpts := []otlptracehttp.Option{
-		otlptracehttp.WithEndpoint(cfg.OtelEndpoint),
-	}
-	if cfg.OtelInsecure {
-		traceOpts = append(traceOpts, otlptracehttp.WithInsecure())
+	// Optionally export log records over OTLP with the same resource
+	var logProvider otellog.LoggerProvider
+	if cfg.isOtlpLogs() {
+		logExp, err := newLogExporter(ctx, cfg)
+		if err != nil {
+			return nil, err
+		}
+		o.LoggerProvider = sdklog.NewLoggerProvider(
+			sdklog.WithResource(res),
+			sdklog.WithProcessor(sdklog.NewBatchProcessor(logExp)),
+		)
+		logProvider = o.LoggerProvider
 	}
-	traceExp, err := otlptracehttp.New(ctx, traceOpts...)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create trace exporter: %w", err)
+	o.Logger = newLogger(&cfg, logProvider)
+
+	// Health checks registered through GetHealthRegistry before InitOtel are kept
+	if cfg.OtelDisableGlobals {
+		o.Health = NewHealthRegistry()
+	} else {
+		o.Health = GetHealthRegistry()
+		o.Health.shuttingDown.Store(false)
 	}
 
-	tp := sdktrace.NewTracerProvider(
-		sdktrace.WithSampler(sdktrace.TraceIDRatioBased(cfg.OtelTracingSampleRate)),
-		sdktrace.WithResource(res),
-		sdktrace.WithSpanProcessor(sdktrace.NewBatchSpanProcessor(traceExp)),
-	)
-	otel.SetTracerProvider(tp)
-
-	// 3. Configure Metrics based on MetricsMode
-	var (
-		mp              *sdkmetric.MeterProvider
-		metricsServer   *http.Server
-		metricsShutdown []func(context.Context) error
-		readers         []sdkmetric.Reader
-	)
+	// 2. Configure Tracing (Push model sending to Otel Collector)
+	var sampler sdktrace.Sampler
+	if cfg.IsTracingEnabled() {
+		switch {
+		case cfg.isStdoutTraces():
+			traceExp, err = newStdoutTraceExporter(cfg, stdoutWriter(cfg, o.Logger, "traces"))
+		case cfg.isFileTraces():
+			traceExp, err = newFileTraceExporter(ctx, cfg)
+		default:
+			traceExp, err = newTraceExporter(ctx, cfg)
+		}
+		if err != nil {
+			return nil, err
+		}
 
-	// Setup metrics exporter(s) based on mode
-	if cfg.IsPull() {
-		// Pull mode: Prometheus exporter
-		promExporter, err := prometheus.New()
+		sampler, err = newSampler(cfg)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create prometheus exporter: %w", err)
+			return nil, fmt.Errorf("failed to create sampler: %w", err)
 		}
-		readers = append(readers, promExporter)
+	}
 
-		// Setup HTTP server for pull metrics
-		mux := http.NewServeMux()
-		mux.Handle(cfg.MetricsPath, promhttp.Handler())
+	// 3. Configure Metrics based on MetricsMode
+	var readers []sdkmetric.Reader
 
-		metricsServer = &http.Server{
-			Addr:    fmt.Sprintf("0.0.0.0:%d", cfg.MetricsPort),
-			Handler: mux,
-		}
+	// Runtime histograms and cardinality overflow detection are attached to every reader
+	pipeline := metricPipeline{overflow: newCardinalityMonitor(o.Logger, cfg.CardinalityLimit)}
+	if cfg.IsRuntimeMetricsEnabled() {
+		pipeline.producers = append(pipeline.producers, newRuntimeProducer())
+	}
 
-		// Try to bind the metrics port immediately so startup failures (e.g., port in use)
-		// are returned to the caller instead of being logged asynchronously.
-		ln, err := net.Listen("tcp", metricsServer.Addr)
+	// Setup metrics exporter(s) based on mode
+	if cfg.IsPull() {
+		// Pull mode: Prometheus exporter served by the internal HTTP server
+		reader, err := o.startMetricsServer(cfg, pipeline)
 		if err != nil {
-			return nil, fmt.Errorf("failed to bind metrics server addr %s: %w", metricsServer.Addr, err)
+			return nil, err
 		}
-
-		go func() {
-			if err := metricsServer.Serve(ln); err != nil && err != http.ErrServerClosed {
-				fmt.Printf("Metrics server error: %v\n", err)
-			}
-		}()
+		readers = append(readers, reader)
 	}
 
 	if cfg.IsPush() {
 		// Push mode: OTLP metrics exporter with protocol support (HTTP or gRPC)
 		pushInterval := time.Duration(cfg.MetricsPushInterval) * time.Second
-		
+
 		// Create OTLP metric exporter based on protocol configuration
 		protocol := strings.ToLower(strings.TrimSpace(cfg.MetricsProtocol))
 		if protocol == "" {
 			protocol = "http"
 		}
 
+		tlsCfg, err := newTLSConfig(cfg.metricsTLS())
+		if err != nil {
+			return nil, fmt.Errorf("failed to load metrics exporter TLS config: %w", err)
+		}
+		ep := parseOtlpEndpoint(cfg.MetricsPushEndpoint, cfg.MetricsInsecure)
+
 		switch protocol {
 		case "grpc":
 			// Use gRPC protocol for OTLP metrics export
 			grpcOpts := []otlpmetricgrpc.Option{
-				otlpmetricgrpc.WithEndpoint(cfg.MetricsPushEndpoint),
+				otlpmetricgrpc.WithEndpoint(ep.host),
 			}
-			if cfg.MetricsInsecure {
+			if ep.insecure {
 				grpcOpts = append(grpcOpts, otlpmetricgrpc.WithInsecure())
+			} else if tlsCfg != nil {
+				grpcOpts = append(grpcOpts, otlpmetricgrpc.WithTLSCredentials(credentials.NewTLS(tlsCfg)))
+			}
+			if len(cfg.MetricsHeaders) > 0 {
+				grpcOpts = append(grpcOpts, otlpmetricgrpc.WithHeaders(cfg.MetricsHeaders))
+			}
+			if isGzip(cfg.MetricsCompression) {
+				grpcOpts = append(grpcOpts, otlpmetricgrpc.WithCompressor("gzip"))
+			}
+			if cfg.MetricsTimeout > 0 {
+				grpcOpts = append(grpcOpts, otlpmetricgrpc.WithTimeout(time.Duration(cfg.MetricsTimeout)*time.Second))
+			}
+			if selector := cfg.histogramAggregationSelector(); selector != nil {
+				grpcOpts = append(grpcOpts, otlpmetricgrpc.WithAggregationSelector(selector))
 			}
 			exp, err := otlpmetricgrpc.New(ctx, grpcOpts...)
 			if err != nil {
 				return nil, fmt.Errorf("failed to create OTLP gRPC metrics exporter: %w", err)
 			}
 
-			reader := sdkmetric.NewPeriodicReader(exp,
-				sdkmetric.WithInterval(pushInterval),
-			)
-			metricsShutdown = append(metricsShutdown, reader.Shutdown)
+			reader := pipeline.periodicReader(exp, pushInterval)
+			o.metricsShutdown = append(o.metricsShutdown, reader.Shutdown)
 			readers = append(readers, reader)
 		case "http":
 			// Use HTTP protocol for OTLP metrics export
 			httpOpts := []otlpmetrichttp.Option{
-				otlpmetrichttp.WithEndpoint(cfg.MetricsPushEndpoint),
+				otlpmetrichttp.WithEndpoint(ep.host),
+			}
+			if ep.path != "" {
+				httpOpts = append(httpOpts, otlpmetrichttp.WithURLPath(ep.path))
 			}
-			if cfg.MetricsInsecure {
+			if ep.insecure {
 				httpOpts = append(httpOpts, otlpmetrichttp.WithInsecure())
+			} else if tlsCfg != nil {
+				httpOpts = append(httpOpts, otlpmetrichttp.WithTLSClientConfig(tlsCfg))
+			}
+			if len(cfg.MetricsHeaders) > 0 {
+				httpOpts = append(httpOpts, otlpmetrichttp.WithHeaders(cfg.MetricsHeaders))
+			}
+			if isGzip(cfg.MetricsCompression) {
+				httpOpts = append(httpOpts, otlpmetrichttp.WithCompression(otlpmetrichttp.GzipCompression))
+			}
+			if cfg.MetricsTimeout > 0 {
+				httpOpts = append(httpOpts, otlpmetrichttp.WithTimeout(time.Duration(cfg.MetricsTimeout)*time.Second))
+			}
+			if selector := cfg.histogramAggregationSelector(); selector != nil {
+				httpOpts = append(httpOpts, otlpmetrichttp.WithAggregationSelector(selector))
 			}
 			exp, err := otlpmetrichttp.New(ctx, httpOpts...)
 			if err != nil {
 				return nil, fmt.Errorf("failed to create OTLP HTTP metrics exporter: %w", err)
 			}
 
-			reader := sdkmetric.NewPeriodicReader(exp,
-				sdkmetric.WithInterval(pushInterval),
-			)
-			metricsShutdown = append(metricsShutdown, reader.Shutdown)
+			reader := pipeline.periodicReader(exp, pushInterval)
+			o.metricsShutdown = append(o.metricsShutdown, reader.Shutdown)
 			readers = append(readers, reader)
 		default:
 			return nil, fmt.Errorf("invalid METRICS_PROTOCOL: %s", protocol)
 		}
 	}
 
-	// If no readers configured, default to pull mode
-	if len(readers) == 0 {
-		promExporter, err := prometheus.New()
+	if cfg.IsStdoutMetrics() {
+		// Stdout mode: periodic snapshots printed locally, no collector or listener needed
+		reader, err := newStdoutMetricReader(cfg, stdoutWriter(cfg, o.Logger, "metrics"), pipeline)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create prometheus exporter: %w", err)
+			return nil, err
 		}
-		readers = append(readers, promExporter)
+		o.metricsShutdown = append(o.metricsShutdown, reader.Shutdown)
+		readers = append(readers, reader)
+	}
 
-		mux := http.NewServeMux()
-		mux.Handle(cfg.MetricsPath, promhttp.Handler())
+	if cfg.IsFileMetrics() {
+		// File mode: OTLP-JSON lines appended to a rotating file set for offline analysis
+		reader, err := newFileMetricReader(ctx, cfg, pipeline)
+		if err != nil {
+			return nil, err
+		}
+		o.metricsShutdown = append(o.metricsShutdown, reader.Shutdown)
+		readers = append(readers, reader)
+	}
 
-		metricsServer = &http.Server{
-			Addr:    fmt.Sprintf("0.0.0.0:%d", cfg.MetricsPort),
-			Handler: mux,
+	// If no readers configured, default to pull mode unless metrics are disabled
+	if len(readers) == 0 && !cfg.IsMetricsDisabled() {
+		reader, err := o.startMetricsServer(cfg, pipeline)
+		if err != nil {
+			return nil, err
 		}
+		readers = append(readers, reader)
+	}
 
-		// Try to bind the metrics port immediately so startup failures (e.g., port in use)
-		// are returned to the caller instead of being logged asynchronously.
-		ln, err := net.Listen("tcp", metricsServer.Addr)
+	// Create MeterProvider with all readers
+	if len(readers) > 0 {
+		views, err := cfg.MetricsViews.sdkViews()
 		if err != nil {
-			return nil, fmt.Errorf("failed to bind metrics server addr %s: %w", metricsServer.Addr, err)
+			return nil, fmt.Errorf("invalid metric views: %w", err)
+		}
+		if pipeline.overflow != nil {
+			// Matches nothing: it maps Prometheus family names to metric names for overflow reports
+			views = append(views, pipeline.overflow.view(views))
+		}
+		opts := []sdkmetric.Option{
+			sdkmetric.WithResource(res),
+			sdkmetric.WithView(views...),
+			sdkmetric.WithCardinalityLimit(cfg.CardinalityLimit),
+			sdkmetric.WithExemplarFilter(cfg.exemplarFilter()),
+		}
+		for _, r := range readers {
+			opts = append(opts, sdkmetric.WithReader(r))
+		}
+		o.MeterProvider = sdkmetric.NewMeterProvider(opts...)
+		if pipeline.overflow != nil {
+			pipeline.overflow.setMeterProvider(o.MeterProvider)
+		}
+		if err := o.Health.registerMetrics(o.MeterProvider); err != nil {
+			return nil, err
+		}
+		if cfg.HealthCheckInterval > 0 {
+			o.healthMonitorStop = o.Health.startMonitor(time.Duration(cfg.HealthCheckInterval) * time.Second)
 		}
 
-		go func() {
-			if err := metricsServer.Serve(ln); err != nil && err != http.ErrServerClosed {
-				fmt.Printf("Metrics server error: %v\n", err)
+		if cfg.IsRuntimeMetricsEnabled() {
+			if err := startRuntimeMetrics(o.MeterProvider); err != nil {
+				return nil, err
 			}
-		}()
+		}
+		if cfg.IsProcessMetricsEnabled() {
+			if err := startProcessMetrics(o.MeterProvider); err != nil {
+				return nil, err
+			}
+		}
 	}
 
-	// Create MeterProvider with all readers
-	opts := []sdkmetric.Option{
-		sdkmetric.WithResource(res),
-	}
-	for _, r := range readers {
-		opts = append(opts, sdkmetric.WithReader(r))
+	// Build the TracerProvider once the MeterProvider exists so span processors can record metrics
+	if traceExp != nil {
+		var spanProcessor sdktrace.SpanProcessor = sdktrace.NewBatchSpanProcessor(traceExp)
+		if cfg.TailSamplingEnabled {
+			ratio, err := tailSamplingRatio(cfg)
+			if err != nil {
+				return nil, err
+			}
+			// Tail sampling needs every span recorded; the ratio is applied once a trace completes
+			sampler = sdktrace.AlwaysSample()
+			spanProcessor = NewTailSamplingProcessor(spanProcessor, TailSamplingConfig{
+				LatencyThreshold: time.Duration(cfg.TailSamplingLatencyMs) * time.Millisecond,
+				Ratio:            ratio,
+				MaxTraces:        cfg.TailSamplingMaxTraces,
+				MaxSpansPerTrace: cfg.TailSamplingMaxSpans,
+				MeterProvider:    o.meterProvider(),
+			})
+		}
+
+		o.TracerProvider = sdktrace.NewTracerProvider(
+			sdktrace.WithSampler(sampler),
+			sdktrace.WithResource(res),
+			sdktrace.WithSpanProcessor(spanProcessor),
+		)
 	}
-	mp = sdkmetric.NewMeterProvider(opts...)
-	otel.SetMeterProvider(mp)
 
-	// 4. Configure Global Propagator (W3C Trace Context & Baggage)
-	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+	// 4. Configure Propagator (W3C Trace Context & Baggage)
+	o.Propagator = propagation.NewCompositeTextMapPropagator(
 		propagation.TraceContext{},
 		propagation.Baggage{},
-	))
+	)
 
-	// Return a Shutdown function to clean up resources when service stops
-	return func(ctx context.Context) error {
-		var errs []string
+	// Debug endpoints get their own server when DEBUG_ADDR is set, otherwise the metrics server.
+	// It starts last so no later step can fail with it already listening.
+	if cfg.isDebugEnabled() && strings.TrimSpace(cfg.DebugAddr) != "" {
+		if err := o.startDebugServer(cfg); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.isDebugEnabled() && o.debugServer == nil && o.metricsServer == nil {
+		o.Logger.Warn("Debug endpoints are not served: set DEBUG_ADDR or use the pull/hybrid metrics mode")
+	}
 
-		// Shutdown push-specific resources (readers/periodic readers) first
-		for _, shutdown := range metricsShutdown {
-			if err := shutdown(ctx); err != nil {
-				errs = append(errs, fmt.Sprintf("push metrics shutdown error: %v", err))
-			}
+	// 5. Install globals unless the caller manages providers explicitly. Disabled signals get
+	// no-op providers.
+	if !cfg.OtelDisableGlobals {
+		otel.SetMeterProvider(o.meterProvider())
+		otel.SetTracerProvider(o.tracerProvider())
+		otel.SetTextMapPropagator(o.Propagator)
+		if o.LoggerProvider != nil {
+			global.SetLoggerProvider(o.LoggerProvider)
+		}
+	}
+
+	return o, nil
+}
+
+// closeOnError releases the servers, exporters and providers created by a failed
+// NewObservability call. traceExp is the trace exporter if no TracerProvider owns it yet.
+func (o *Observability) closeOnError(traceExp sdktrace.SpanExporter) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if o.healthMonitorStop != nil {
+		o.healthMonitorStop()
+	}
+	// Close the listener too: Serve may not have started tracking it yet
+	if o.metricsServer != nil {
+		_ = o.metricsServer.Close()
+		_ = o.metricsListener.Close()
+	}
+	if o.debugServer != nil {
+		_ = o.debugServer.Close()
+		_ = o.debugListener.Close()
+	}
+	if o.TracerProvider != nil {
+		_ = o.TracerProvider.Shutdown(ctx)
+	} else if traceExp != nil {
+		_ = traceExp.Shutdown(ctx)
+	}
+	if o.MeterProvider != nil {
+		_ = o.MeterProvider.Shutdown(ctx)
+	} else {
+		for _, shutdown := range o.metricsShutdown {
+			_ = shutdown(ctx)
 		}
+	}
+	if o.LoggerProvider != nil {
+		_ = o.LoggerProvider.Shutdown(ctx)
+	}
+}
+
+// Tracer returns a tracer from the handle's TracerProvider
+func (o *Observability) Tracer(name string) trace.Tracer {
+	return o.tracerProvider().Tracer(name)
+}
+
+// Meter returns a meter from the handle's MeterProvider
+func (o *Observability) Meter(name string) metric.Meter {
+	return o.meterProvider().Meter(name)
+}
+
+// tracerProvider returns the SDK TracerProvider, or a no-op provider when tracing is disabled
+func (o *Observability) tracerProvider() trace.TracerProvider {
+	if o.TracerProvider == nil {
+		return tracenoop.NewTracerProvider()
+	}
+	return o.TracerProvider
+}
+
+// meterProvider returns the SDK MeterProvider, or a no-op provider when metrics are disabled
+func (o *Observability) meterProvider() metric.MeterProvider {
+	if o.MeterProvider == nil {
+		return metricnoop.NewMeterProvider()
+	}
+	return o.MeterProvider
+}
+
+// MiddlewareConfig returns a middleware configuration bound to the handle's providers and
+// propagator, for use with the *WithConfig Gin and gRPC middleware when globals are disabled
+func (o *Observability) MiddlewareConfig() *ObservabilityMiddlewareConfig {
+	return &ObservabilityMiddlewareConfig{
+		TracerProvider: o.tracerProvider(),
+		MeterProvider:  o.meterProvider(),
+		Propagator:     o.Propagator,
+	}
+}
 
-		// ForceFlush Meter Provider to ensure all metrics are sent before shutdown
-		if err := mp.ForceFlush(ctx); err != nil {
+// metricPipeline holds what NewObservability attaches to every metric reader
+type metricPipeline struct {
+	// producers supply metrics computed outside the SDK, such as the runtime histograms
+	producers []sdkmetric.Producer
+	// overflow reports cardinality overflows (nil when the limit is disabled)
+	overflow *cardinalityMonitor
+}
+
+// periodicReader creates a periodic reader exporting to exp every interval with the pipeline's
+// producers, checking each export for cardinality overflow
+func (p metricPipeline) periodicReader(exp sdkmetric.Exporter, interval time.Duration) *sdkmetric.PeriodicReader {
+	if p.overflow != nil {
+		exp = p.overflow.exporter(exp)
+	}
+	opts := []sdkmetric.PeriodicReaderOption{sdkmetric.WithInterval(interval)}
+	for _, producer := range p.producers {
+		opts = append(opts, sdkmetric.WithProducer(producer))
+	}
+	return sdkmetric.NewPeriodicReader(exp, opts...)
+}
+
+// startMetricsServer creates the Prometheus exporter on a dedicated registry and serves it at
+// MetricsPath. The port is bound immediately so startup failures (e.g., port in use) are returned
+// to the caller instead of being logged asynchronously.
+func (o *Observability) startMetricsServer(cfg BaseConfig, pipeline metricPipeline) (sdkmetric.Reader, error) {
+	if o.metricsServer != nil {
+		return nil, fmt.Errorf("metrics server already started")
+	}
+
+	// The OTel metrics get their own registry. In global mode the default registry, with its Go and
+	// process collectors and anything registered through prometheus.MustRegister, is served too.
+	registry := prom.NewRegistry()
+	var gatherer prom.Gatherer = prom.Gatherers{registry, prom.DefaultGatherer}
+	if cfg.OtelDisableGlobals {
+		registry.MustRegister(
+			collectors.NewGoCollector(),
+			collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		)
+		gatherer = registry
+	}
+
+	promOpts := []prometheus.Option{prometheus.WithRegisterer(registry)}
+	for _, p := range pipeline.producers {
+		promOpts = append(promOpts, prometheus.WithProducer(p))
+	}
+	if selector := cfg.histogramAggregationSelector(); selector != nil {
+		// Exponential histograms are exposed as native histograms to protobuf scrapes
+		promOpts = append(promOpts, prometheus.WithAggregationSelector(selector))
+	}
+	promExporter, err := prometheus.New(promOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create prometheus exporter: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	if pipeline.overflow != nil {
+		gatherer = pipeline.overflow.gatherer(gatherer)
+	}
+	// OpenMetrics is negotiated by scrapers that ask for it and is the only format carrying exemplars
+	mux.Handle(cfg.MetricsPath, requireAuth("metrics", cfg.metricsAuth(),
+		promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{EnableOpenMetrics: true})))
+	for path, handler := range map[string]http.Handler{
+		LivenessPath:  o.Health.LivenessHandler(),
+		ReadinessPath: o.Health.ReadinessHandler(),
+		HealthPath:    o.Health.HealthHandler(),
+	} {
+		if path != cfg.MetricsPath {
+			mux.Handle(path, handler)
+		}
+	}
+	if strings.TrimSpace(cfg.DebugAddr) == "" {
+		handlers, err := debugHandlers(cfg)
+		if err != nil {
+			return nil, err
+		}
+		for path, handler := range handlers {
+			mux.Handle(path, handler)
+		}
+	}
+
+	tlsCfg, err := newServerTLSConfig(cfg.metricsServerTLS())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load metrics server TLS config: %w", err)
+	}
+
+	server := &http.Server{
+		Addr:      net.JoinHostPort(strings.TrimSpace(cfg.MetricsHost), strconv.Itoa(cfg.MetricsPort)),
+		Handler:   mux,
+		TLSConfig: tlsCfg,
+	}
+
+	ln, err := net.Listen("tcp", server.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind metrics server addr %s: %w", server.Addr, err)
+	}
+
+	if tlsCfg != nil {
+		ln = tls.NewListener(ln, tlsCfg)
+	}
+
+	go func() {
+		if err := server.Serve(ln); err != nil && err != http.ErrServerClosed {
+			o.Logger.Error("Metrics server error", "error", err)
+		}
+	}()
+
+	o.Registry = registry
+	o.metricsServer = server
+	o.metricsAddr = ln.Addr()
+	o.metricsListener = ln
+	return promExporter, nil
+}
+
+// MetricsAddr returns the address the metrics server is bound to, or "" if no server runs.
+// With MetricsPort 0 this reports the port picked by the OS.
+func (o *Observability) MetricsAddr() string {
+	if o.metricsAddr == nil {
+		return ""
+	}
+	return o.metricsAddr.String()
+}
+
+// ForceFlush exports all pending metrics and spans
+func (o *Observability) ForceFlush(ctx context.Context) error {
+	var errs []string
+
+	// ForceFlush Meter Provider to ensure all metrics are sent
+	if o.MeterProvider != nil {
+		if err := o.MeterProvider.ForceFlush(ctx); err != nil {
 			errs = append(errs, fmt.Sprintf("meter provider force flush error: %v", err))
 		}
+	}
 
-		// ForceFlush Tracer Provider to ensure all traces are sent before shutdown
-		if err := tp.ForceFlush(ctx); err != nil {
+	// ForceFlush Tracer Provider to ensure all traces are sent
+	if o.TracerProvider != nil {
+		if err := o.TracerProvider.ForceFlush(ctx); err != nil {
 			errs = append(errs, fmt.Sprintf("tracer provider force flush error: %v", err))
 		}
+	}
 
-		// Shutdown Metrics Server (if pull mode enabled)
-		if metricsServer != nil {
-			if err := metricsServer.Shutdown(ctx); err != nil {
-				errs = append(errs, fmt.Sprintf("metrics server shutdown error: %v", err))
-			}
+	// ForceFlush Logger Provider to ensure all log records are sent
+	if o.LoggerProvider != nil {
+		if err := o.LoggerProvider.ForceFlush(ctx); err != nil {
+			errs = append(errs, fmt.Sprintf("logger provider force flush error: %v", err))
 		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("otel force flush failures: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// Shutdown flushes and releases all telemetry resources. Call it when the service stops.
+func (o *Observability) Shutdown(ctx context.Context) error {
+	var errs []string
 
-		// Shutdown Tracer Provider
-		if err := tp.Shutdown(ctx); err != nil {
+	// Fail readiness first so traffic drains while telemetry is flushed
+	if o.Health != nil {
+		o.Health.SetShuttingDown()
+	}
+	if o.healthMonitorStop != nil {
+		o.healthMonitorStop()
+	}
+
+	// Shutdown push-specific resources (readers/periodic readers) first
+	for _, shutdown := range o.metricsShutdown {
+		if err := shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Sprintf("push metrics shutdown error: %v", err))
+		}
+	}
+
+	// ForceFlush Meter Provider to ensure all metrics are sent before shutdown
+	if o.MeterProvider != nil {
+		if err := o.MeterProvider.ForceFlush(ctx); err != nil {
+			errs = append(errs, fmt.Sprintf("meter provider force flush error: %v", err))
+		}
+	}
+
+	// ForceFlush Tracer Provider to ensure all traces are sent before shutdown
+	if o.TracerProvider != nil {
+		if err := o.TracerProvider.ForceFlush(ctx); err != nil {
+			errs = append(errs, fmt.Sprintf("tracer provider force flush error: %v", err))
+		}
+	}
+
+	// Shutdown Metrics Server (if pull mode enabled)
+	if o.metricsServer != nil {
+		if err := o.metricsServer.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Sprintf("metrics server shutdown error: %v", err))
+		}
+	}
+
+	// Shutdown Debug Server (if DEBUG_ADDR is set)
+	if o.debugServer != nil {
+		if err := o.debugServer.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Sprintf("debug server shutdown error: %v", err))
+		}
+	}
+
+	// Shutdown Tracer Provider
+	if o.TracerProvider != nil {
+		if err := o.TracerProvider.Shutdown(ctx); err != nil {
 			errs = append(errs, fmt.Sprintf("tracer provider shutdown error: %v", err))
 		}
+	}
 
-		// Shutdown Meter Provider
-		if err := mp.Shutdown(ctx); err != nil {
+	// Shutdown Meter Provider
+	if o.MeterProvider != nil {
+		if err := o.MeterProvider.Shutdown(ctx); err != nil {
 			errs = append(errs, fmt.Sprintf("meter provider shutdown error: %v", err))
 		}
+	}
 
-		if len(errs) > 0 {
-			return fmt.Errorf("otel shutdown failures: %s", strings.Join(errs, "; "))
+	// Shutdown Logger Provider last so records logged during shutdown are still exported
+	o.Logger.Sync()
+	if o.LoggerProvider != nil {
+		if err := o.LoggerProvider.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Sprintf("logger provider shutdown error: %v", err))
 		}
-		return nil
-	}, nil
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("otel shutdown failures: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// newTraceExporter creates the OTLP trace exporter based on the TracesProtocol configuration
+func newTraceExporter(ctx context.Context, cfg BaseConfig) (sdktrace.SpanExporter, error) {
+	protocol := strings.ToLower(strings.TrimSpace(cfg.TracesProtocol))
+	if protocol == "" {
+		protocol = "http"
+	}
+
+	tlsCfg, err := newTLSConfig(cfg.traceTLS())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load trace exporter TLS config: %w", err)
+	}
+	ep := parseOtlpEndpoint(cfg.OtelEndpoint, cfg.OtelInsecure)
+
+	switch protocol {
+	case "grpc":
+		// Use gRPC protocol for OTLP trace export
+		grpcOpts := []otlptracegrpc.Option{
+			otlptracegrpc.WithEndpoint(ep.host),
+		}
+		if ep.insecure {
+			grpcOpts = append(grpcOpts, otlptracegrpc.WithInsecure())
+		} else if tlsCfg != nil {
+			grpcOpts = append(grpcOpts, otlptracegrpc.WithTLSCredentials(credentials.NewTLS(tlsCfg)))
+		}
+		if len(cfg.OtelHeaders) > 0 {
+			grpcOpts = append(grpcOpts, otlptracegrpc.WithHeaders(cfg.OtelHeaders))
+		}
+		if isGzip(cfg.OtelCompression) {
+			grpcOpts = append(grpcOpts, otlptracegrpc.WithCompressor("gzip"))
+		}
+		if cfg.OtelTimeout > 0 {
+			grpcOpts = append(grpcOpts, otlptracegrpc.WithTimeout(time.Duration(cfg.OtelTimeout)*time.Second))
+		}
+		exp, err := otlptracegrpc.New(ctx, grpcOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create OTLP gRPC trace exporter: %w", err)
+		}
+		return exp, nil
+	case "http":
+		// Use HTTP protocol for OTLP trace export, respecting insecure config
+		httpOpts := []otlptracehttp.Option{
+			otlptracehttp.WithEndpoint(ep.host),
+		}
+		if ep.path != "" {
+			httpOpts = append(httpOpts, otlptracehttp.WithURLPath(ep.path))
+		}
+		if ep.insecure {
+			httpOpts = append(httpOpts, otlptracehttp.WithInsecure())
+		} else if tlsCfg != nil {
+			httpOpts = append(httpOpts, otlptracehttp.WithTLSClientConfig(tlsCfg))
+		}
+		if len(cfg.OtelHeaders) > 0 {
+			httpOpts = append(httpOpts, otlptracehttp.WithHeaders(cfg.OtelHeaders))
+		}
+		if isGzip(cfg.OtelCompression) {
+			httpOpts = append(httpOpts, otlptracehttp.WithCompression(otlptracehttp.GzipCompression))
+		}
+		if cfg.OtelTimeout > 0 {
+			httpOpts = append(httpOpts, otlptracehttp.WithTimeout(time.Duration(cfg.OtelTimeout)*time.Second))
+		}
+		exp, err := otlptracehttp.New(ctx, httpOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create trace exporter: %w", err)
+		}
+		return exp, nil
+	default:
+		return nil, fmt.Errorf("invalid OTEL_TRACES_PROTOCOL: %s", protocol)
+	}
+}
+
+// isGzip returns true if the compression setting selects gzip
+func isGzip(compression string) bool {
+	return strings.ToLower(strings.TrimSpace(compression)) == "gzip"
 }
 
 // GetTracer returns a tracer instance
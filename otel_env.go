@@ -0,0 +1,158 @@
+package observability
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// otlpEndpointEnv is the spec-defined base endpoint shared by all signals
+const otlpEndpointEnv = "OTEL_EXPORTER_OTLP_ENDPOINT"
+
+// ResourceAttributes holds resource attributes parsed from the OTEL_RESOURCE_ATTRIBUTES
+// "k=v,k2=v2" format (comma-separated, percent-encoded values)
+type ResourceAttributes map[string]string
+
+// SetValue parses a "k=v,k2=v2" string (cleanenv Setter interface)
+func (r *ResourceAttributes) SetValue(s string) error {
+	parsed, err := parseKeyValueList(s, "resource attribute")
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}
+
+// otlpSignalEndpoints lists the endpoint fields filled from OTEL_EXPORTER_OTLP_ENDPOINT, with the
+// variables taking precedence over it and the path appended to the base URL
+var otlpSignalEndpoints = []struct {
+	field string
+	envs  []string
+	path  string
+}{
+	{field: "OtelEndpoint", envs: []string{"OTEL_ENDPOINT", "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"}, path: "/v1/traces"},
+	{field: "MetricsPushEndpoint", envs: []string{"METRICS_PUSH_ENDPOINT", "OTEL_EXPORTER_OTLP_METRICS_ENDPOINT"}, path: "/v1/metrics"},
+	{field: "LogsEndpoint", envs: []string{"OTEL_LOGS_ENDPOINT", "OTEL_EXPORTER_OTLP_LOGS_ENDPOINT"}, path: "/v1/logs"},
+}
+
+// applyOtlpEndpointFallback fills endpoint fields from OTEL_EXPORTER_OTLP_ENDPOINT when neither the
+// project-specific nor the signal-specific variable is set. As in the OTLP spec, the signal path is
+// appended to a base URL; a plain host:port is used unchanged.
+func applyOtlpEndpointFallback(cfg any) {
+	base := strings.TrimSpace(os.Getenv(otlpEndpointEnv))
+	if base == "" {
+		return
+	}
+
+	v := reflect.ValueOf(cfg)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return
+	}
+
+	for _, signal := range otlpSignalEndpoints {
+		f := v.FieldByName(signal.field)
+		if !f.IsValid() || !f.CanSet() || f.Kind() != reflect.String {
+			continue
+		}
+		if anyEnvSet(signal.envs...) {
+			continue
+		}
+		f.SetString(signalEndpoint(base, signal.path))
+	}
+}
+
+// anyEnvSet returns true if one of the environment variables is set to a non-empty value
+func anyEnvSet(names ...string) bool {
+	for _, name := range names {
+		if strings.TrimSpace(os.Getenv(name)) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// signalEndpoint appends the signal path to a base endpoint URL
+func signalEndpoint(base, path string) string {
+	if !isEndpointURL(base) {
+		return base
+	}
+	return strings.TrimRight(base, "/") + path
+}
+
+// isEndpointURL returns true if the endpoint is a full URL rather than host:port
+func isEndpointURL(endpoint string) bool {
+	return strings.Contains(endpoint, "://")
+}
+
+// otlpEndpoint is an exporter endpoint split into host:port, URL path and TLS choice
+type otlpEndpoint struct {
+	host     string
+	path     string
+	insecure bool
+}
+
+// parseOtlpEndpoint splits a host:port or http(s) URL endpoint. For URLs the scheme decides TLS
+// and a non-root path replaces the exporter's default signal path; host:port endpoints use the
+// insecure flag.
+func parseOtlpEndpoint(endpoint string, insecure bool) otlpEndpoint {
+	endpoint = strings.TrimSpace(endpoint)
+	if !isEndpointURL(endpoint) {
+		return otlpEndpoint{host: endpoint, insecure: insecure}
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return otlpEndpoint{host: endpoint, insecure: insecure}
+	}
+
+	ep := otlpEndpoint{
+		host:     u.Host,
+		insecure: strings.EqualFold(u.Scheme, "http"),
+	}
+	if u.Path != "" && u.Path != "/" {
+		ep.path = u.Path
+	}
+	return ep
+}
+
+// validateEndpoint checks that a URL endpoint has an http(s) scheme and a host. The value is not
+// echoed since URLs may carry credentials.
+func validateEndpoint(env, endpoint string) error {
+	endpoint = strings.TrimSpace(endpoint)
+	if endpoint == "" || !isEndpointURL(endpoint) {
+		return nil
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid %s: not a valid URL", env)
+	}
+	if !strings.EqualFold(u.Scheme, "http") && !strings.EqualFold(u.Scheme, "https") {
+		return fmt.Errorf("invalid %s: URL scheme must be 'http' or 'https'", env)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("invalid %s: URL has no host", env)
+	}
+	return nil
+}
+
+// envResourceAttributes returns the OTEL_RESOURCE_ATTRIBUTES entries sorted by key
+func envResourceAttributes(attrs ResourceAttributes) []attribute.KeyValue {
+	keys := make([]string, 0, len(attrs))
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	kvs := make([]attribute.KeyValue, 0, len(keys))
+	for _, k := range keys {
+		kvs = append(kvs, attribute.String(k, attrs[k]))
+	}
+	return kvs
+}
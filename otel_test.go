@@ -2,9 +2,19 @@ package observability
 
 import (
 	"context"
+	"io"
 	"net"
+	"net/http"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
+
+	prom "github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/propagation"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
 )
 
 func TestInitOtel(t *testing.T) {
@@ -205,6 +215,40 @@ func TestInitOtel(t *testing.T) {
 		defer cancel()
 		_ = shutdown(ctx) // Ignore error as collector may not be running
 	})
+
+	t.Run("Init Success with gRPC Traces Protocol", func(t *testing.T) {
+		cfgTraceGRPC := cfg
+		cfgTraceGRPC.MetricsPort = 19096
+		cfgTraceGRPC.OtelEndpoint = "localhost:4317"
+		cfgTraceGRPC.TracesProtocol = "grpc"
+
+		shutdown, err := InitOtel(cfgTraceGRPC)
+		if err != nil {
+			t.Fatalf("InitOtel with gRPC traces protocol failed: %v", err)
+		}
+		if shutdown == nil {
+			t.Fatal("shutdown function is nil")
+		}
+
+		_, span := GetTracer("test-tracer-grpc").Start(context.Background(), "test-span")
+		span.End()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		_ = shutdown(ctx) // Ignore error as collector may not be running
+	})
+
+	t.Run("Invalid Traces Protocol", func(t *testing.T) {
+		cfgInvalid := cfg
+		cfgInvalid.MetricsPort = 19097
+		cfgInvalid.TracesProtocol = "thrift"
+
+		shutdown, err := InitOtel(cfgInvalid)
+		if err == nil {
+			_ = shutdown(context.Background())
+			t.Fatal("expected InitOtel to fail for invalid traces protocol")
+		}
+	})
 }
 
 func TestInitOtel_DefaultsToPullWhenNoMode(t *testing.T) {
@@ -279,3 +323,285 @@ func TestInitOtel_BindFailure(t *testing.T) {
 		t.Fatalf("expected InitOtel to fail binding to occupied port %d, but it succeeded", tcpAddr.Port)
 	}
 }
+
+func TestNewObservability(t *testing.T) {
+	cfg := BaseConfig{
+		ServiceName:           "test-otel-handle",
+		Version:               "1.0.0",
+		OtelEndpoint:          "localhost:4318",
+		OtelInsecure:          true,
+		OtelTracingSampleRate: 1.0,
+		MetricsPort:           0, // let the OS pick a free port
+		MetricsMode:           "pull",
+		MetricsPath:           "/metrics",
+	}
+
+	o, err := NewObservability(cfg)
+	if err != nil {
+		t.Fatalf("NewObservability failed: %v", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		_ = o.Shutdown(ctx) // Ignore error as collector may not be running
+	}()
+
+	if o.TracerProvider == nil || o.MeterProvider == nil || o.Registry == nil || o.Logger == nil {
+		t.Fatalf("expected all handle fields to be set, got %+v", o)
+	}
+
+	addr := o.MetricsAddr()
+	_, port, err := net.SplitHostPort(addr)
+	if err != nil || port == "0" {
+		t.Fatalf("expected a bound metrics address, got %q", addr)
+	}
+
+	// Record a metric through the handle's MeterProvider and scrape it from the bound address
+	counter, err := o.MeterProvider.Meter("handle-test").Int64Counter("handle_requests")
+	if err != nil {
+		t.Fatalf("failed to create counter: %v", err)
+	}
+	counter.Add(context.Background(), 3)
+
+	resp, err := http.Get("http://" + addr + "/metrics")
+	if err != nil {
+		t.Fatalf("failed to scrape metrics: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, _ := io.ReadAll(resp.Body)
+	if !strings.Contains(string(body), `handle_requests_total{otel_scope_name="handle-test"`) {
+		t.Errorf("expected handle_requests_total in scrape output, got:\n%s", body)
+	}
+
+	// The registry is gathered directly as well
+	families, err := o.Registry.Gather()
+	if err != nil || len(families) == 0 {
+		t.Errorf("expected registry to gather metric families, got %d (err=%v)", len(families), err)
+	}
+
+	_, span := o.TracerProvider.Tracer("handle-test").Start(context.Background(), "op")
+	span.End()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	_ = o.ForceFlush(ctx) // Ignore error as collector may not be running
+}
+
+func TestNewObservability_PushModeHasNoMetricsServer(t *testing.T) {
+	cfg := BaseConfig{
+		ServiceName:           "test-otel-handle-push",
+		Version:               "1.0.0",
+		OtelEndpoint:          "localhost:4318",
+		OtelInsecure:          true,
+		OtelTracingSampleRate: 1.0,
+		MetricsMode:           "push",
+		MetricsPushEndpoint:   "localhost:4318",
+		MetricsPushInterval:   1,
+		MetricsProtocol:       "http",
+		MetricsInsecure:       true,
+	}
+
+	o, err := NewObservability(cfg)
+	if err != nil {
+		t.Fatalf("NewObservability failed: %v", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		_ = o.Shutdown(ctx) // Ignore error as collector may not be running
+	}()
+
+	if addr := o.MetricsAddr(); addr != "" {
+		t.Errorf("expected no metrics address in push mode, got %q", addr)
+	}
+	if o.Registry != nil {
+		t.Error("expected no Prometheus registry in push mode")
+	}
+}
+
+func TestNewObservability_DisableGlobals(t *testing.T) {
+	installTestTracing(t)
+	origTP := otel.GetTracerProvider()
+	origMP := otel.GetMeterProvider()
+
+	cfg := BaseConfig{
+		ServiceName:           "test-otel-no-globals",
+		Version:               "1.0.0",
+		OtelEndpoint:          "localhost:4318",
+		OtelInsecure:          true,
+		OtelTracingSampleRate: 1.0,
+		MetricsPort:           0,
+		MetricsMode:           "pull",
+		MetricsPath:           "/metrics",
+		OtelDisableGlobals:    true,
+	}
+
+	// Two handles can coexist in one process without touching the globals
+	first, err := NewObservability(cfg)
+	if err != nil {
+		t.Fatalf("NewObservability failed: %v", err)
+	}
+	second, err := NewObservability(cfg)
+	if err != nil {
+		t.Fatalf("second NewObservability failed: %v", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		_ = first.Shutdown(ctx)  // Ignore error as collector may not be running
+		_ = second.Shutdown(ctx) // Ignore error as collector may not be running
+	}()
+
+	_, propagatorUntouched := otel.GetTextMapPropagator().(propagation.TraceContext)
+	if otel.GetTracerProvider() != origTP || otel.GetMeterProvider() != origMP || !propagatorUntouched {
+		t.Error("expected globals to stay untouched when OtelDisableGlobals is set")
+	}
+	if first.MetricsAddr() == second.MetricsAddr() {
+		t.Errorf("expected distinct metrics addresses, got %s twice", first.MetricsAddr())
+	}
+
+	mwCfg := first.MiddlewareConfig()
+	if mwCfg.TracerProvider != first.TracerProvider || mwCfg.MeterProvider != first.MeterProvider || mwCfg.Propagator == nil {
+		t.Error("expected middleware config bound to the handle's providers")
+	}
+	_, span := first.Tracer("no-globals").Start(context.Background(), "op")
+	if !span.SpanContext().IsValid() {
+		t.Error("expected handle tracer to create valid spans")
+	}
+	span.End()
+}
+
+func TestInitOtel_DisabledTelemetry(t *testing.T) {
+	installTestTracing(t)
+	origMP := otel.GetMeterProvider()
+	defer otel.SetMeterProvider(origMP)
+
+	cfg := BaseConfig{
+		ServiceName:     "test-otel-disabled",
+		Version:         "1.0.0",
+		TracingDisabled: true,
+		MetricsMode:     "none",
+		MetricsPort:     19094,
+		MetricsPath:     "/metrics",
+	}
+
+	o, err := NewObservability(cfg)
+	if err != nil {
+		t.Fatalf("NewObservability failed: %v", err)
+	}
+	if o.TracerProvider != nil || o.MeterProvider != nil || o.Registry != nil {
+		t.Error("expected no SDK providers or registry when telemetry is disabled")
+	}
+	if o.MetricsAddr() != "" {
+		t.Errorf("expected no metrics listener, got %s", o.MetricsAddr())
+	}
+	if _, ok := otel.GetTracerProvider().(tracenoop.TracerProvider); !ok {
+		t.Errorf("expected a no-op global TracerProvider, got %T", otel.GetTracerProvider())
+	}
+	if _, ok := otel.GetMeterProvider().(metricnoop.MeterProvider); !ok {
+		t.Errorf("expected a no-op global MeterProvider, got %T", otel.GetMeterProvider())
+	}
+
+	_, span := o.Tracer("disabled").Start(context.Background(), "op")
+	if span.IsRecording() {
+		t.Error("expected non-recording spans when tracing is disabled")
+	}
+	span.End()
+
+	if _, err := net.DialTimeout("tcp", "127.0.0.1:19094", 100*time.Millisecond); err == nil {
+		t.Error("expected nothing listening on METRICS_PORT when METRICS_MODE=none")
+	}
+
+	shutdown, err := InitOtel(cfg)
+	if err != nil {
+		t.Fatalf("InitOtel failed: %v", err)
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("expected no-op shutdown to succeed, got %v", err)
+	}
+	if err := o.Shutdown(context.Background()); err != nil {
+		t.Errorf("expected handle shutdown to succeed, got %v", err)
+	}
+}
+
+func TestNewObservability_ReleasesOnError(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to reserve a port: %v", err)
+	}
+	port := ln.Addr().(*net.TCPAddr).Port
+	_ = ln.Close()
+
+	// The views fail after the metrics server is already listening
+	_, err = NewObservability(BaseConfig{
+		ServiceName:        "test-otel-release",
+		Version:            "1.0.0",
+		TracingDisabled:    true,
+		MetricsMode:        "pull",
+		MetricsHost:        "127.0.0.1",
+		MetricsPath:        "/metrics",
+		MetricsPort:        port,
+		MetricsViews:       MetricViews{{Buckets: []float64{1}}},
+		OtelDisableGlobals: true,
+	})
+	if err == nil {
+		t.Fatal("expected NewObservability to fail for an invalid view")
+	}
+
+	ln, err = net.Listen("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(port)))
+	if err != nil {
+		t.Fatalf("expected the metrics port to be released, got %v", err)
+	}
+	_ = ln.Close()
+}
+
+func TestNewObservability_ServesDefaultRegistry(t *testing.T) {
+	appCounter := prom.NewCounter(prom.CounterOpts{Name: "app_legacy_jobs_total", Help: "Jobs processed"})
+	prom.MustRegister(appCounter)
+	defer prom.Unregister(appCounter)
+	appCounter.Inc()
+
+	tests := []struct {
+		name          string
+		disableGlobal bool
+		want          bool
+	}{
+		{name: "Global Mode", disableGlobal: false, want: true},
+		{name: "Non Global Mode", disableGlobal: true, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o, err := NewObservability(BaseConfig{
+				ServiceName:        "test-otel-default-registry",
+				Version:            "1.0.0",
+				TracingDisabled:    true,
+				MetricsMode:        "pull",
+				MetricsPath:        "/metrics",
+				MetricsPort:        0,
+				OtelDisableGlobals: tt.disableGlobal,
+			})
+			if err != nil {
+				t.Fatalf("NewObservability failed: %v", err)
+			}
+			defer func() { _ = o.Shutdown(context.Background()) }()
+
+			resp, err := http.Get("http://" + o.MetricsAddr() + "/metrics")
+			if err != nil {
+				t.Fatalf("failed to scrape metrics: %v", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+			body, _ := io.ReadAll(resp.Body)
+
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("expected 200, got %d:\n%s", resp.StatusCode, body)
+			}
+			if got := strings.Contains(string(body), "app_legacy_jobs_total 1"); got != tt.want {
+				t.Errorf("expected the default registry collector present=%v, got:\n%s", tt.want, body)
+			}
+			if !strings.Contains(string(body), "go_goroutines") {
+				t.Errorf("expected the Go collector in both modes, got:\n%s", body)
+			}
+		})
+	}
+}
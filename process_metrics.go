@@ -0,0 +1,398 @@
+package observability
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// processScopeName is the instrumentation scope of the process and container metrics
+const processScopeName = "process"
+
+// clockTicks is USER_HZ, the unit of the CPU times in /proc/<pid>/stat on every mainstream Linux
+const clockTicks = 100
+
+// cgroupV1Unlimited is the smallest cgroup v1 memory limit treated as "no limit" (the kernel
+// reports PAGE_COUNTER_MAX rounded to the page size)
+const cgroupV1Unlimited = int64(1) << 62
+
+// processStats reads process statistics from a /proc/<pid> directory and container limits from a
+// cgroup v1 or v2 hierarchy. Files that cannot be read, e.g. outside Linux, are skipped.
+type processStats struct {
+	procDir   string
+	cgroupDir string
+
+	mu       sync.Mutex
+	lastCPU  float64
+	lastTime time.Time
+}
+
+// startProcessMetrics registers observable instruments for the current process on mp
+func startProcessMetrics(mp metric.MeterProvider) error {
+	return newProcessStats("/proc/self", "/sys/fs/cgroup").register(mp)
+}
+
+// initTime approximates the process start time where /proc cannot be read
+var initTime = time.Now()
+
+// processStartTime returns when the current process started, read from /proc on Linux and
+// approximated by the package initialization time elsewhere
+func processStartTime() time.Time {
+	if start, err := newProcessStats("/proc/self", "/sys/fs/cgroup").startTime(); err == nil {
+		return start
+	}
+	return initTime
+}
+
+// newProcessStats returns a reader of the given /proc/<pid> and cgroup root directories
+func newProcessStats(procDir, cgroupDir string) *processStats {
+	return &processStats{procDir: procDir, cgroupDir: cgroupDir}
+}
+
+// register creates the process and container instruments and a callback observing them
+func (p *processStats) register(mp metric.MeterProvider) error {
+	meter := mp.Meter(processScopeName)
+
+	cpuTime, err := meter.Float64ObservableCounter("process.cpu.time",
+		metric.WithDescription("Total CPU seconds consumed by the process, by cpu.mode"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create process CPU time counter: %w", err)
+	}
+	rss, err := meter.Int64ObservableGauge("process.memory.usage",
+		metric.WithDescription("Resident set size of the process"),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create process memory gauge: %w", err)
+	}
+	fds, err := meter.Int64ObservableGauge("process.open_file_descriptor.count",
+		metric.WithDescription("Number of file descriptors open in the process"),
+		metric.WithUnit("{file_descriptor}"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create open file descriptor gauge: %w", err)
+	}
+	threads, err := meter.Int64ObservableGauge("process.thread.count",
+		metric.WithDescription("Number of OS threads of the process"),
+		metric.WithUnit("{thread}"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create thread gauge: %w", err)
+	}
+	memLimit, err := meter.Int64ObservableGauge("container.memory.limit",
+		metric.WithDescription("cgroup memory limit of the container (absent when unlimited)"),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create container memory limit gauge: %w", err)
+	}
+	cpuLimit, err := meter.Float64ObservableGauge("container.cpu.limit",
+		metric.WithDescription("cgroup CPU quota of the container in CPUs (absent when unlimited)"),
+		metric.WithUnit("{cpu}"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create container CPU limit gauge: %w", err)
+	}
+	throttled, err := meter.Float64ObservableCounter("container.cpu.throttled.time",
+		metric.WithDescription("Total time the container was throttled by its cgroup CPU quota"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create container CPU throttling counter: %w", err)
+	}
+	memUtil, err := meter.Float64ObservableGauge("process.memory.limit.utilization",
+		metric.WithDescription("Resident set size as a fraction of the container memory limit"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create memory utilization gauge: %w", err)
+	}
+	cpuUtil, err := meter.Float64ObservableGauge("process.cpu.limit.utilization",
+		metric.WithDescription("CPU usage since the previous collection as a fraction of the container CPU quota"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create CPU utilization gauge: %w", err)
+	}
+
+	userMode := metric.WithAttributes(attribute.String("cpu.mode", "user"))
+	systemMode := metric.WithAttributes(attribute.String("cpu.mode", "system"))
+
+	_, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		stat, statErr := p.stat()
+		if statErr == nil {
+			o.ObserveFloat64(cpuTime, stat.user, userMode)
+			o.ObserveFloat64(cpuTime, stat.system, systemMode)
+			o.ObserveInt64(threads, stat.threads)
+		}
+		rssBytes, rssErr := p.rss()
+		if rssErr == nil {
+			o.ObserveInt64(rss, rssBytes)
+		}
+		if n, err := p.openFDs(); err == nil {
+			o.ObserveInt64(fds, n)
+		}
+
+		if limit, ok := p.memoryLimit(); ok {
+			o.ObserveInt64(memLimit, limit)
+			if rssErr == nil {
+				o.ObserveFloat64(memUtil, float64(rssBytes)/float64(limit))
+			}
+		}
+		if sec, ok := p.throttledSeconds(); ok {
+			o.ObserveFloat64(throttled, sec)
+		}
+		cpus, hasCPULimit := p.cpuLimit()
+		if hasCPULimit {
+			o.ObserveFloat64(cpuLimit, cpus)
+		}
+		if statErr == nil {
+			if rate, ok := p.cpuRate(stat.user+stat.system, time.Now()); ok && hasCPULimit {
+				o.ObserveFloat64(cpuUtil, rate/cpus)
+			}
+		}
+		return nil
+	}, cpuTime, rss, fds, threads, memLimit, cpuLimit, throttled, memUtil, cpuUtil)
+	if err != nil {
+		return fmt.Errorf("failed to register process metrics callback: %w", err)
+	}
+	return nil
+}
+
+// procStat holds the fields read from /proc/<pid>/stat
+type procStat struct {
+	user, system float64
+	threads      int64
+	startTicks   uint64
+}
+
+// stat parses CPU times (seconds) and the thread count from /proc/<pid>/stat
+func (p *processStats) stat() (procStat, error) {
+	data, err := os.ReadFile(filepath.Join(p.procDir, "stat"))
+	if err != nil {
+		return procStat{}, err
+	}
+	// The command name is parenthesized and may contain spaces; fields resume after the last ')'
+	s := string(data)
+	end := strings.LastIndexByte(s, ')')
+	if end < 0 {
+		return procStat{}, fmt.Errorf("malformed stat file")
+	}
+	// fields[0] is field 3 (state) of proc(5)
+	fields := strings.Fields(s[end+1:])
+	if len(fields) < 18 {
+		return procStat{}, fmt.Errorf("malformed stat file")
+	}
+	utime, err := strconv.ParseUint(fields[11], 10, 64)
+	if err != nil {
+		return procStat{}, err
+	}
+	stime, err := strconv.ParseUint(fields[12], 10, 64)
+	if err != nil {
+		return procStat{}, err
+	}
+	threads, err := strconv.ParseInt(fields[17], 10, 64)
+	if err != nil {
+		return procStat{}, err
+	}
+	startTicks, err := strconv.ParseUint(fields[19], 10, 64)
+	if err != nil {
+		return procStat{}, err
+	}
+	return procStat{
+		user:       float64(utime) / clockTicks,
+		system:     float64(stime) / clockTicks,
+		threads:    threads,
+		startTicks: startTicks,
+	}, nil
+}
+
+// startTime returns the process start time: the boot time (btime) of the stat file next to
+// /proc/<pid> plus the start time in clock ticks since boot of /proc/<pid>/stat
+func (p *processStats) startTime() (time.Time, error) {
+	stat, err := p.stat()
+	if err != nil {
+		return time.Time{}, err
+	}
+	data, err := os.ReadFile(filepath.Join(filepath.Dir(p.procDir), "stat"))
+	if err != nil {
+		return time.Time{}, err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[0] == "btime" {
+			btime, err := strconv.ParseInt(fields[1], 10, 64)
+			if err != nil {
+				return time.Time{}, err
+			}
+			since := time.Duration(stat.startTicks) * time.Second / clockTicks
+			return time.Unix(btime, 0).Add(since), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("no btime in stat file")
+}
+
+// rss returns the resident set size in bytes from /proc/<pid>/statm
+func (p *processStats) rss() (int64, error) {
+	data, err := os.ReadFile(filepath.Join(p.procDir, "statm"))
+	if err != nil {
+		return 0, err
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) < 2 {
+		return 0, fmt.Errorf("malformed statm file")
+	}
+	pages, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return pages * int64(os.Getpagesize()), nil
+}
+
+// openFDs counts the entries of /proc/<pid>/fd
+func (p *processStats) openFDs() (int64, error) {
+	entries, err := os.ReadDir(filepath.Join(p.procDir, "fd"))
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(entries)), nil
+}
+
+// cpuRate returns the CPUs used since the previous call, given the total CPU seconds at now
+func (p *processStats) cpuRate(cpu float64, now time.Time) (float64, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	prevCPU, prevTime := p.lastCPU, p.lastTime
+	p.lastCPU, p.lastTime = cpu, now
+	elapsed := now.Sub(prevTime).Seconds()
+	if prevTime.IsZero() || elapsed <= 0 || cpu < prevCPU {
+		return 0, false
+	}
+	return (cpu - prevCPU) / elapsed, true
+}
+
+// memoryLimit returns the cgroup memory limit in bytes, if one is set
+func (p *processStats) memoryLimit() (int64, bool) {
+	if p.isCgroupV2() {
+		v, ok := p.readCgroupFile("", "memory.max")
+		if !ok || v == "max" {
+			return 0, false
+		}
+		limit, err := strconv.ParseInt(v, 10, 64)
+		return limit, err == nil && limit > 0
+	}
+	v, ok := p.readCgroupFile("memory", "memory.limit_in_bytes")
+	if !ok {
+		return 0, false
+	}
+	limit, err := strconv.ParseInt(v, 10, 64)
+	return limit, err == nil && limit > 0 && limit < cgroupV1Unlimited
+}
+
+// cpuLimit returns the cgroup CPU quota in CPUs, if one is set
+func (p *processStats) cpuLimit() (float64, bool) {
+	var quota, period string
+	if p.isCgroupV2() {
+		v, ok := p.readCgroupFile("", "cpu.max")
+		fields := strings.Fields(v)
+		if !ok || len(fields) != 2 || fields[0] == "max" {
+			return 0, false
+		}
+		quota, period = fields[0], fields[1]
+	} else {
+		var ok bool
+		if quota, ok = p.readCgroupFile("cpu", "cpu.cfs_quota_us"); !ok {
+			return 0, false
+		}
+		if period, ok = p.readCgroupFile("cpu", "cpu.cfs_period_us"); !ok {
+			return 0, false
+		}
+	}
+
+	q, err := strconv.ParseFloat(quota, 64)
+	if err != nil || q <= 0 {
+		return 0, false
+	}
+	per, err := strconv.ParseFloat(period, 64)
+	if err != nil || per <= 0 {
+		return 0, false
+	}
+	return q / per, true
+}
+
+// throttledSeconds returns the total time the cgroup was throttled from its cpu.stat file
+func (p *processStats) throttledSeconds() (float64, bool) {
+	key, scale, controller := "throttled_time", 1e-9, "cpu"
+	if p.isCgroupV2() {
+		key, scale, controller = "throttled_usec", 1e-6, ""
+	}
+	v, ok := p.readCgroupFile(controller, "cpu.stat")
+	if !ok {
+		return 0, false
+	}
+	for _, line := range strings.Split(v, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[0] == key {
+			n, err := strconv.ParseUint(fields[1], 10, 64)
+			return float64(n) * scale, err == nil
+		}
+	}
+	return 0, false
+}
+
+// isCgroupV2 reports whether the cgroup root is a unified (v2) hierarchy
+func (p *processStats) isCgroupV2() bool {
+	_, err := os.Stat(filepath.Join(p.cgroupDir, "cgroup.controllers"))
+	return err == nil
+}
+
+// readCgroupFile reads a file of the process's cgroup for controller ("" for cgroup v2). The
+// cgroup's own directory is tried first, then the root, which is what a container usually sees.
+func (p *processStats) readCgroupFile(controller, name string) (string, bool) {
+	base := filepath.Join(p.cgroupDir, controller)
+	dirs := []string{base}
+	if rel := p.cgroupPath(controller); rel != "" && rel != "/" {
+		dirs = []string{filepath.Join(base, rel), base}
+	}
+	for _, dir := range dirs {
+		if data, err := os.ReadFile(filepath.Join(dir, name)); err == nil {
+			return strings.TrimSpace(string(data)), true
+		}
+	}
+	return "", false
+}
+
+// cgroupPath returns the process's cgroup path for controller from /proc/<pid>/cgroup, where
+// lines read "hierarchy-id:controller-list:path" and cgroup v2 has an empty controller list
+func (p *processStats) cgroupPath(controller string) string {
+	data, err := os.ReadFile(filepath.Join(p.procDir, "cgroup"))
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		parts := strings.SplitN(line, ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		if controller == "" && parts[0] == "0" && parts[1] == "" {
+			return parts[2]
+		}
+		for _, c := range strings.Split(parts[1], ",") {
+			if controller != "" && c == controller {
+				return parts[2]
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,272 @@
+package observability
+
+import (
+	"context"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// writeFiles creates files under dir from a map of relative path to content
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("failed to create %s: %v", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", path, err)
+		}
+	}
+}
+
+// fakeProc creates a /proc/<pid> directory with 250/50 ticks of CPU, 7 threads, 1000 resident
+// pages and 3 open file descriptors
+func fakeProc(t *testing.T, cgroup string) string {
+	t.Helper()
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"stat":   "42 (my svc (v2)) S 1 42 42 0 -1 4194304 79 0 0 0 250 50 0 0 20 0 7 0 447937 2703360 1000 18446744073709551615\n",
+		"statm":  "5000 1000 288 5 0 123 0\n",
+		"cgroup": cgroup,
+		"fd/0":   "",
+		"fd/1":   "",
+		"fd/2":   "",
+	})
+	return dir
+}
+
+func TestProcessStats(t *testing.T) {
+	procDir := fakeProc(t, "0::/\n")
+	p := newProcessStats(procDir, t.TempDir())
+
+	stat, err := p.stat()
+	if err != nil {
+		t.Fatalf("stat failed: %v", err)
+	}
+	if stat.user != 2.5 || stat.system != 0.5 || stat.threads != 7 {
+		t.Errorf("unexpected stat %+v", stat)
+	}
+	if rss, err := p.rss(); err != nil || rss != 1000*int64(os.Getpagesize()) {
+		t.Errorf("expected 1000 pages of RSS, got %d (err=%v)", rss, err)
+	}
+	if n, err := p.openFDs(); err != nil || n != 3 {
+		t.Errorf("expected 3 open file descriptors, got %d (err=%v)", n, err)
+	}
+
+	now := time.Now()
+	if _, ok := p.cpuRate(3, now); ok {
+		t.Error("expected no CPU rate without a previous sample")
+	}
+	if rate, ok := p.cpuRate(4, now.Add(2*time.Second)); !ok || rate != 0.5 {
+		t.Errorf("expected a rate of 0.5 CPUs, got %v (ok=%v)", rate, ok)
+	}
+}
+
+func TestProcessStatsStartTime(t *testing.T) {
+	procRoot := t.TempDir()
+	writeFiles(t, procRoot, map[string]string{
+		"stat": "cpu  10 0 10 100 0 0 0 0 0 0\nbtime 1700000000\nprocesses 42\n",
+	})
+	procDir := filepath.Join(procRoot, "42")
+	writeFiles(t, procDir, map[string]string{
+		"stat": "42 (my svc (v2)) S 1 42 42 0 -1 4194304 79 0 0 0 250 50 0 0 20 0 7 0 447937 2703360 1000 18446744073709551615\n",
+	})
+
+	start, err := newProcessStats(procDir, t.TempDir()).startTime()
+	if err != nil {
+		t.Fatalf("startTime failed: %v", err)
+	}
+	if want := time.Unix(1700000000, 0).Add(4479370 * time.Millisecond); !start.Equal(want) {
+		t.Errorf("expected start time %v, got %v", want, start)
+	}
+
+	if _, err := newProcessStats(fakeProc(t, ""), t.TempDir()).startTime(); err == nil {
+		t.Error("expected an error without a btime line")
+	}
+}
+
+func TestProcessStatsCgroupLimits(t *testing.T) {
+	tests := []struct {
+		name       string
+		procCgroup string
+		files      map[string]string
+		wantMem    int64
+		wantCPU    float64
+		wantThrot  float64
+		wantLimits bool
+	}{
+		{
+			name:       "Cgroup V2",
+			procCgroup: "0::/\n",
+			files: map[string]string{
+				"cgroup.controllers": "cpu memory\n",
+				"memory.max":         "536870912\n",
+				"cpu.max":            "150000 100000\n",
+				"cpu.stat":           "usage_usec 100\nnr_throttled 3\nthrottled_usec 2500000\n",
+			},
+			wantMem: 536870912, wantCPU: 1.5, wantThrot: 2.5, wantLimits: true,
+		},
+		{
+			name:       "Cgroup V2 Unlimited",
+			procCgroup: "0::/\n",
+			files: map[string]string{
+				"cgroup.controllers": "cpu memory\n",
+				"memory.max":         "max\n",
+				"cpu.max":            "max 100000\n",
+			},
+			wantLimits: false,
+		},
+		{
+			name:       "Cgroup V1 Nested",
+			procCgroup: "4:memory:/pod/abc\n2:cpu,cpuacct:/pod/abc\n",
+			files: map[string]string{
+				"memory/pod/abc/memory.limit_in_bytes": "268435456\n",
+				"memory/memory.limit_in_bytes":         "9223372036854771712\n",
+				"cpu/pod/abc/cpu.cfs_quota_us":         "50000\n",
+				"cpu/pod/abc/cpu.cfs_period_us":        "100000\n",
+				"cpu/pod/abc/cpu.stat":                 "nr_periods 10\nnr_throttled 2\nthrottled_time 1500000000\n",
+			},
+			wantMem: 268435456, wantCPU: 0.5, wantThrot: 1.5, wantLimits: true,
+		},
+		{
+			name:       "Cgroup V1 Unlimited",
+			procCgroup: "4:memory:/\n1:cpu:/\n",
+			files: map[string]string{
+				"memory/memory.limit_in_bytes": "9223372036854771712\n",
+				"cpu/cpu.cfs_quota_us":         "-1\n",
+				"cpu/cpu.cfs_period_us":        "100000\n",
+			},
+			wantLimits: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cgroupDir := t.TempDir()
+			writeFiles(t, cgroupDir, tt.files)
+			p := newProcessStats(fakeProc(t, tt.procCgroup), cgroupDir)
+
+			mem, memOK := p.memoryLimit()
+			cpu, cpuOK := p.cpuLimit()
+			if memOK != tt.wantLimits || cpuOK != tt.wantLimits {
+				t.Fatalf("expected limits present=%v, got memory=%v cpu=%v", tt.wantLimits, memOK, cpuOK)
+			}
+			if !tt.wantLimits {
+				return
+			}
+			if mem != tt.wantMem || cpu != tt.wantCPU {
+				t.Errorf("expected limits %d bytes / %v CPUs, got %d / %v", tt.wantMem, tt.wantCPU, mem, cpu)
+			}
+			if sec, ok := p.throttledSeconds(); !ok || math.Abs(sec-tt.wantThrot) > 1e-9 {
+				t.Errorf("expected %vs throttled, got %v (ok=%v)", tt.wantThrot, sec, ok)
+			}
+		})
+	}
+}
+
+func TestProcessMetrics(t *testing.T) {
+	cgroupDir := t.TempDir()
+	writeFiles(t, cgroupDir, map[string]string{
+		"cgroup.controllers": "cpu memory\n",
+		"memory.max":         "4096000\n",
+		"cpu.max":            "200000 100000\n",
+		"cpu.stat":           "throttled_usec 0\n",
+	})
+	p := newProcessStats(fakeProc(t, "0::/\n"), cgroupDir)
+
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	defer func() { _ = mp.Shutdown(context.Background()) }()
+	if err := p.register(mp); err != nil {
+		t.Fatalf("register failed: %v", err)
+	}
+
+	collect := func() map[string]metricdata.Aggregation {
+		var rm metricdata.ResourceMetrics
+		if err := reader.Collect(context.Background(), &rm); err != nil {
+			t.Fatalf("collect failed: %v", err)
+		}
+		got := map[string]metricdata.Aggregation{}
+		for _, sm := range rm.ScopeMetrics {
+			for _, m := range sm.Metrics {
+				got[m.Name] = m.Data
+			}
+		}
+		return got
+	}
+
+	got := collect()
+	cpuTime, ok := got["process.cpu.time"].(metricdata.Sum[float64])
+	if !ok || len(cpuTime.DataPoints) != 2 {
+		t.Fatalf("expected user and system CPU time, got %+v", got["process.cpu.time"])
+	}
+	for _, dp := range cpuTime.DataPoints {
+		mode, _ := dp.Attributes.Value(attribute.Key("cpu.mode"))
+		if (mode.AsString() == "user" && dp.Value != 2.5) || (mode.AsString() == "system" && dp.Value != 0.5) {
+			t.Errorf("unexpected CPU time %v for mode %s", dp.Value, mode.AsString())
+		}
+	}
+
+	rss := float64(1000 * os.Getpagesize())
+	if util, ok := got["process.memory.limit.utilization"].(metricdata.Gauge[float64]); !ok || util.DataPoints[0].Value != rss/4096000 {
+		t.Errorf("expected memory utilization %v, got %+v", rss/4096000, got["process.memory.limit.utilization"])
+	}
+	if limit, ok := got["container.cpu.limit"].(metricdata.Gauge[float64]); !ok || limit.DataPoints[0].Value != 2 {
+		t.Errorf("expected a CPU limit of 2, got %+v", got["container.cpu.limit"])
+	}
+	for _, name := range []string{"process.memory.usage", "process.open_file_descriptor.count", "process.thread.count", "container.memory.limit"} {
+		if _, ok := got[name].(metricdata.Gauge[int64]); !ok {
+			t.Errorf("expected a %s gauge, got %+v", name, got[name])
+		}
+	}
+	if _, ok := got["process.cpu.limit.utilization"]; ok {
+		t.Error("expected no CPU utilization before a second collection")
+	}
+
+	// The CPU time is unchanged, so the second collection reports an idle process
+	if util, ok := collect()["process.cpu.limit.utilization"].(metricdata.Gauge[float64]); !ok || util.DataPoints[0].Value != 0 {
+		t.Errorf("expected a CPU utilization of 0, got %+v", util)
+	}
+}
+
+func TestNewObservability_ProcessMetrics(t *testing.T) {
+	if _, err := os.Stat("/proc/self/stat"); err != nil {
+		t.Skip("no /proc filesystem")
+	}
+
+	o, err := NewObservability(BaseConfig{
+		ServiceName:        "test-otel-process",
+		Version:            "1.0.0",
+		TracingDisabled:    true,
+		MetricsMode:        "pull",
+		MetricsPath:        "/metrics",
+		MetricsPort:        0,
+		OtelDisableGlobals: true,
+	})
+	if err != nil {
+		t.Fatalf("NewObservability failed: %v", err)
+	}
+	defer func() { _ = o.Shutdown(context.Background()) }()
+
+	families, err := o.Registry.Gather()
+	if err != nil {
+		t.Fatalf("gather failed: %v", err)
+	}
+	found := map[string]bool{}
+	for _, f := range families {
+		found[f.GetName()] = true
+	}
+	for _, name := range []string{"process_cpu_time_seconds_total", "process_memory_usage_bytes", "process_thread_count"} {
+		if !found[name] {
+			t.Errorf("expected %s in the scrape", name)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package observability
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+// Resource detector names accepted by RESOURCE_DETECTORS
+const (
+	DetectorHost            = "host"
+	DetectorProcess         = "process"
+	DetectorContainer       = "container"
+	DetectorK8s             = "k8s"
+	DetectorServiceInstance = "service_instance"
+)
+
+// validDetectors lists the detectors accepted by RESOURCE_DETECTORS
+var validDetectors = map[string]bool{
+	DetectorHost:            true,
+	DetectorProcess:         true,
+	DetectorContainer:       true,
+	DetectorK8s:             true,
+	DetectorServiceInstance: true,
+}
+
+// cgroupPath is the file read by the container detector (overridable in tests)
+var cgroupPath = "/proc/self/cgroup"
+
+// containerIDPattern matches a 64 hex character container ID at the end of a cgroup path,
+// optionally wrapped by runtime prefixes/suffixes such as "docker-<id>.scope" or "cri-containerd-<id>"
+var containerIDPattern = regexp.MustCompile(`([0-9a-f]{64})(?:\.scope)?$`)
+
+// processInstanceID is the generated service.instance.id shared by logs and telemetry of this process
+var (
+	processInstanceID     string
+	processInstanceIDOnce sync.Once
+)
+
+// hasDetector reports whether the detector is enabled in the configuration
+func (b *BaseConfig) hasDetector(name string) bool {
+	for _, d := range b.ResourceDetectors {
+		if strings.ToLower(strings.TrimSpace(d)) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// resourceAttributes returns the identity attributes shared by the OTel resource and log fields.
+// service.name and service.version are always first.
+func resourceAttributes(cfg *BaseConfig) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		semconv.ServiceName(cfg.ServiceName),
+		semconv.ServiceVersion(cfg.Version),
+	}
+
+	if env := strings.TrimSpace(cfg.DeploymentEnvironment); env != "" {
+		attrs = append(attrs, semconv.DeploymentEnvironment(env))
+	}
+	if id := serviceInstanceID(cfg); id != "" {
+		attrs = append(attrs, semconv.ServiceInstanceID(id))
+	}
+
+	if cfg.hasDetector(DetectorHost) {
+		if host, err := os.Hostname(); err == nil && host != "" {
+			attrs = append(attrs, semconv.HostName(host))
+		}
+	}
+
+	if cfg.hasDetector(DetectorProcess) {
+		attrs = append(attrs,
+			semconv.ProcessPID(os.Getpid()),
+			semconv.ProcessRuntimeName("go"),
+			semconv.ProcessRuntimeVersion(runtime.Version()),
+		)
+	}
+
+	if cfg.hasDetector(DetectorContainer) {
+		if id := detectContainerID(); id != "" {
+			attrs = append(attrs, semconv.ContainerID(id))
+		}
+	}
+
+	if cfg.hasDetector(DetectorK8s) {
+		attrs = append(attrs, k8sAttributes()...)
+	}
+
+	return mergeEnvResourceAttributes(cfg, attrs)
+}
+
+// mergeEnvResourceAttributes applies OTEL_RESOURCE_ATTRIBUTES on top of the detected attributes.
+// Explicitly configured service attributes keep precedence over the environment.
+func mergeEnvResourceAttributes(cfg *BaseConfig, attrs []attribute.KeyValue) []attribute.KeyValue {
+	protected := map[attribute.Key]bool{
+		semconv.ServiceNameKey:    true,
+		semconv.ServiceVersionKey: true,
+	}
+	if strings.TrimSpace(cfg.DeploymentEnvironment) != "" {
+		protected[semconv.DeploymentEnvironmentKey] = true
+	}
+	if strings.TrimSpace(cfg.ServiceInstanceID) != "" {
+		protected[semconv.ServiceInstanceIDKey] = true
+	}
+
+	index := make(map[attribute.Key]int, len(attrs))
+	for i, kv := range attrs {
+		index[kv.Key] = i
+	}
+	for _, kv := range envResourceAttributes(cfg.OtelResourceAttributes) {
+		if protected[kv.Key] {
+			continue
+		}
+		if i, ok := index[kv.Key]; ok {
+			attrs[i] = kv
+			continue
+		}
+		attrs = append(attrs, kv)
+	}
+	return attrs
+}
+
+// serviceInstanceID returns the configured instance ID, or a per-process generated one when
+// the service_instance detector is enabled
+func serviceInstanceID(cfg *BaseConfig) string {
+	if id := strings.TrimSpace(cfg.ServiceInstanceID); id != "" {
+		return id
+	}
+	if !cfg.hasDetector(DetectorServiceInstance) {
+		return ""
+	}
+
+	processInstanceIDOnce.Do(func() {
+		processInstanceID = uuid.NewString()
+	})
+	return processInstanceID
+}
+
+// detectContainerID reads the container ID from the cgroup file, or "" outside a container
+func detectContainerID() string {
+	f, err := os.Open(cgroupPath)
+	if err != nil {
+		return ""
+	}
+	defer func() { _ = f.Close() }()
+
+	return parseContainerID(f)
+}
+
+// parseContainerID extracts the container ID from /proc/self/cgroup content (cgroup v1 and v2)
+func parseContainerID(r io.Reader) string {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		// Format: hierarchy-ID:controller-list:cgroup-path
+		parts := strings.SplitN(line, ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		if m := containerIDPattern.FindStringSubmatch(parts[2]); m != nil {
+			return m[1]
+		}
+	}
+	return ""
+}
+
+// k8sAttributes reads pod, namespace and node names exposed through the Kubernetes downward API
+func k8sAttributes() []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	if v := firstEnv("K8S_POD_NAME", "POD_NAME"); v != "" {
+		attrs = append(attrs, semconv.K8SPodName(v))
+	}
+	if v := firstEnv("K8S_POD_UID", "POD_UID"); v != "" {
+		attrs = append(attrs, semconv.K8SPodUID(v))
+	}
+	if v := firstEnv("K8S_NAMESPACE_NAME", "POD_NAMESPACE"); v != "" {
+		attrs = append(attrs, semconv.K8SNamespaceName(v))
+	}
+	if v := firstEnv("K8S_NODE_NAME", "NODE_NAME"); v != "" {
+		attrs = append(attrs, semconv.K8SNodeName(v))
+	}
+	return attrs
+}
+
+// firstEnv returns the first non-empty environment variable among names
+func firstEnv(names ...string) string {
+	for _, name := range names {
+		if v := strings.TrimSpace(os.Getenv(name)); v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,219 @@
+package observability
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const testContainerID = "9f3c8a1b2d4e5f60718293a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4"
+
+func TestParseContainerID(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "Cgroup v1 Docker",
+			content: "12:memory:/docker/" + testContainerID + "\n11:cpu:/docker/" + testContainerID + "\n",
+			want:    testContainerID,
+		},
+		{
+			name:    "Cgroup v2 Systemd Scope",
+			content: "0::/system.slice/docker-" + testContainerID + ".scope\n",
+			want:    testContainerID,
+		},
+		{
+			name:    "Kubernetes Containerd",
+			content: "0::/kubepods/burstable/pod1234/cri-containerd-" + testContainerID + "\n",
+			want:    testContainerID,
+		},
+		{
+			name:    "Not In Container",
+			content: "0::/user.slice/user-1000.slice/session-2.scope\n",
+			want:    "",
+		},
+		{
+			name:    "Malformed",
+			content: "garbage\n",
+			want:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseContainerID(strings.NewReader(tt.content)); got != tt.want {
+				t.Errorf("parseContainerID() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func attributeMap(attrs []attribute.KeyValue) map[string]interface{} {
+	m := make(map[string]interface{}, len(attrs))
+	for _, kv := range attrs {
+		m[string(kv.Key)] = kv.Value.AsInterface()
+	}
+	return m
+}
+
+func TestResourceAttributes(t *testing.T) {
+	// Point the container detector at a fake cgroup file
+	origCgroup := cgroupPath
+	cgroupPath = filepath.Join(t.TempDir(), "cgroup")
+	defer func() { cgroupPath = origCgroup }()
+	if err := os.WriteFile(cgroupPath, []byte("0::/docker/"+testContainerID+"\n"), 0600); err != nil {
+		t.Fatalf("failed to write cgroup file: %v", err)
+	}
+
+	t.Setenv("K8S_POD_NAME", "api-7d9f")
+	t.Setenv("POD_NAMESPACE", "payments")
+	t.Setenv("K8S_NODE_NAME", "node-1")
+
+	t.Run("All Detectors", func(t *testing.T) {
+		cfg := &BaseConfig{
+			ServiceName:           "res-service",
+			Version:               "v1",
+			DeploymentEnvironment: "staging",
+			ResourceDetectors:     []string{"host", "process", "container", "k8s", "service_instance"},
+		}
+		attrs := attributeMap(resourceAttributes(cfg))
+
+		hostname, _ := os.Hostname()
+		expected := map[string]interface{}{
+			"service.name":           "res-service",
+			"service.version":        "v1",
+			"deployment.environment": "staging",
+			"host.name":              hostname,
+			"process.pid":            int64(os.Getpid()),
+			"process.runtime.name":   "go",
+			"container.id":           testContainerID,
+			"k8s.pod.name":           "api-7d9f",
+			"k8s.namespace.name":     "payments",
+			"k8s.node.name":          "node-1",
+		}
+		for k, v := range expected {
+			if attrs[k] != v {
+				t.Errorf("expected %s=%v, got %v", k, v, attrs[k])
+			}
+		}
+
+		id, ok := attrs["service.instance.id"].(string)
+		if !ok || id == "" {
+			t.Fatal("expected generated service.instance.id")
+		}
+		// The generated ID is stable for the process so logs and telemetry agree
+		if again := attributeMap(resourceAttributes(cfg))["service.instance.id"]; again != id {
+			t.Errorf("expected stable service.instance.id, got %v and %v", id, again)
+		}
+	})
+
+	t.Run("Detectors Disabled", func(t *testing.T) {
+		cfg := &BaseConfig{ServiceName: "res-service", Version: "v1"}
+		attrs := resourceAttributes(cfg)
+		if len(attrs) != 2 {
+			t.Errorf("expected only service.name and service.version, got %v", attributeMap(attrs))
+		}
+	})
+
+	t.Run("Explicit Instance ID", func(t *testing.T) {
+		cfg := &BaseConfig{ServiceName: "res-service", ServiceInstanceID: "instance-42"}
+		if got := attributeMap(resourceAttributes(cfg))["service.instance.id"]; got != "instance-42" {
+			t.Errorf("expected configured service.instance.id, got %v", got)
+		}
+	})
+}
+
+func TestNewLoggerIncludesResourceFields(t *testing.T) {
+	t.Setenv("K8S_POD_NAME", "api-7d9f")
+
+	// Capture stdout written by the JSON core
+	origStdout := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("failed to create pipe: %v", err)
+	}
+	os.Stdout = w
+
+	l := NewLogger(&BaseConfig{
+		ServiceName:           "log-res-service",
+		DeploymentEnvironment: "prod",
+		ServiceInstanceID:     "instance-1",
+		ResourceDetectors:     []string{"k8s"},
+	})
+	l.Info("hello")
+	l.Sync()
+
+	os.Stdout = origStdout
+	_ = w.Close()
+
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, r); err != nil {
+		t.Fatalf("failed to read log output: %v", err)
+	}
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(bytes.TrimSpace(buf.Bytes()), &entry); err != nil {
+		t.Fatalf("failed to decode log line %q: %v", buf.String(), err)
+	}
+
+	expected := map[string]interface{}{
+		"service":                "log-res-service",
+		"deployment.environment": "prod",
+		"service.instance.id":    "instance-1",
+		"k8s.pod.name":           "api-7d9f",
+	}
+	for k, v := range expected {
+		if entry[k] != v {
+			t.Errorf("expected log field %s=%v, got %v", k, v, entry[k])
+		}
+	}
+}
+
+func TestLoadCfgResourceDetectors(t *testing.T) {
+	defer func() { _ = os.Unsetenv("RESOURCE_DETECTORS") }()
+
+	_ = os.Unsetenv("LOG_LEVEL")
+	_ = os.Setenv("SERVICE_NAME", "detectors-service")
+	_ = os.Setenv("METRICS_MODE", "pull")
+
+	t.Run("Defaults Enable All", func(t *testing.T) {
+		_ = os.Unsetenv("RESOURCE_DETECTORS")
+
+		var cfg BaseConfig
+		if err := LoadCfg(&cfg); err != nil {
+			t.Fatalf("LoadCfg failed: %v", err)
+		}
+		if len(cfg.ResourceDetectors) != 5 {
+			t.Errorf("expected 5 default detectors, got %v", cfg.ResourceDetectors)
+		}
+	})
+
+	t.Run("Subset", func(t *testing.T) {
+		_ = os.Setenv("RESOURCE_DETECTORS", "host,k8s")
+
+		var cfg BaseConfig
+		if err := LoadCfg(&cfg); err != nil {
+			t.Fatalf("LoadCfg failed: %v", err)
+		}
+		if !cfg.hasDetector(DetectorHost) || cfg.hasDetector(DetectorProcess) {
+			t.Errorf("unexpected detectors: %v", cfg.ResourceDetectors)
+		}
+	})
+
+	t.Run("Invalid Detector", func(t *testing.T) {
+		_ = os.Setenv("RESOURCE_DETECTORS", "host,gce")
+
+		var cfg BaseConfig
+		if err := LoadCfg(&cfg); err == nil {
+			t.Error("expected LoadCfg to fail for unknown detector")
+		}
+	})
+}
@@ -0,0 +1,178 @@
+package observability
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"runtime/metrics"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// runtimeScopeName is the instrumentation scope of the Go runtime metrics
+const runtimeScopeName = "go-runtime"
+
+// runtime/metrics sample names read by the runtime instrumentation
+const (
+	runtimeGoroutines   = "/sched/goroutines:goroutines"
+	runtimeHeapAlloc    = "/memory/classes/heap/objects:bytes"
+	runtimeGoMaxProcs   = "/sched/gomaxprocs:threads"
+	runtimeGCPauses     = "/sched/pauses/total/gc:seconds"
+	runtimeSchedLatency = "/sched/latencies:seconds"
+)
+
+// runtimeDurationBounds are the histogram boundaries (seconds) the fine-grained runtime
+// histograms are folded into, keeping the number of exported buckets small
+var runtimeDurationBounds = []float64{
+	0.000001, 0.00001, 0.00005, 0.0001, 0.0005, 0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1,
+}
+
+// startRuntimeMetrics registers observable gauges for the goroutine count, heap allocation and
+// GOMAXPROCS on mp
+func startRuntimeMetrics(mp metric.MeterProvider) error {
+	meter := mp.Meter(runtimeScopeName)
+
+	goroutines, err := meter.Int64ObservableGauge("go.goroutine.count",
+		metric.WithDescription("Count of live goroutines"),
+		metric.WithUnit("{goroutine}"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create goroutine gauge: %w", err)
+	}
+	heapAlloc, err := meter.Int64ObservableGauge("go.memory.heap.alloc",
+		metric.WithDescription("Bytes of allocated heap objects"),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create heap allocation gauge: %w", err)
+	}
+	maxProcs, err := meter.Int64ObservableGauge("go.processor.limit",
+		metric.WithDescription("Number of OS threads that can execute user-level Go code simultaneously (GOMAXPROCS)"),
+		metric.WithUnit("{thread}"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create GOMAXPROCS gauge: %w", err)
+	}
+
+	_, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		samples := []metrics.Sample{
+			{Name: runtimeGoroutines},
+			{Name: runtimeHeapAlloc},
+			{Name: runtimeGoMaxProcs},
+		}
+		metrics.Read(samples)
+		for i, inst := range []metric.Int64Observable{goroutines, heapAlloc, maxProcs} {
+			if samples[i].Value.Kind() == metrics.KindUint64 {
+				o.ObserveInt64(inst, int64(samples[i].Value.Uint64()))
+			}
+		}
+		return nil
+	}, goroutines, heapAlloc, maxProcs)
+	if err != nil {
+		return fmt.Errorf("failed to register runtime metrics callback: %w", err)
+	}
+	return nil
+}
+
+// runtimeProducer is a metric producer exposing the GC pause and scheduler latency histograms
+// precomputed by the Go runtime. Histograms cannot be observed asynchronously through the
+// metrics API, so the producer is registered on every reader instead of the MeterProvider.
+type runtimeProducer struct {
+	mu    sync.Mutex
+	start time.Time
+}
+
+var _ sdkmetric.Producer = (*runtimeProducer)(nil)
+
+// newRuntimeProducer returns a producer whose cumulative histograms start with the process, as
+// the runtime counts from there
+func newRuntimeProducer() *runtimeProducer {
+	return &runtimeProducer{start: processStartTime()}
+}
+
+// Produce reads the runtime histograms and returns them as cumulative OTel histograms
+func (p *runtimeProducer) Produce(context.Context) ([]metricdata.ScopeMetrics, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	samples := []metrics.Sample{
+		{Name: runtimeGCPauses},
+		{Name: runtimeSchedLatency},
+	}
+	metrics.Read(samples)
+	now := time.Now()
+
+	var out []metricdata.Metrics
+	for _, m := range []struct {
+		sample      metrics.Sample
+		name, descr string
+	}{
+		{samples[0], "go.gc.pause.duration", "Distribution of stop-the-world pause latencies caused by the garbage collector"},
+		{samples[1], "go.schedule.duration", "Time goroutines have spent runnable before actually running"},
+	} {
+		if m.sample.Value.Kind() != metrics.KindFloat64Histogram {
+			continue
+		}
+		out = append(out, metricdata.Metrics{
+			Name:        m.name,
+			Description: m.descr,
+			Unit:        "s",
+			Data: metricdata.Histogram[float64]{
+				Temporality: metricdata.CumulativeTemporality,
+				DataPoints: []metricdata.HistogramDataPoint[float64]{
+					foldRuntimeHistogram(m.sample.Value.Float64Histogram(), p.start, now),
+				},
+			},
+		})
+	}
+	if len(out) == 0 {
+		return nil, nil
+	}
+	return []metricdata.ScopeMetrics{{
+		Scope:   instrumentation.Scope{Name: runtimeScopeName},
+		Metrics: out,
+	}}, nil
+}
+
+// foldRuntimeHistogram converts a runtime histogram into a data point with
+// runtimeDurationBounds. Each runtime bucket is counted in the first bound at or above its upper
+// edge, and the sum is estimated from bucket lower edges since the runtime does not track it.
+func foldRuntimeHistogram(h *metrics.Float64Histogram, start, now time.Time) metricdata.HistogramDataPoint[float64] {
+	counts := make([]uint64, len(runtimeDurationBounds)+1)
+	var count uint64
+	var sum float64
+	for i, c := range h.Counts {
+		if c == 0 {
+			continue
+		}
+		lower, upper := h.Buckets[i], h.Buckets[i+1]
+		idx := len(runtimeDurationBounds)
+		for j, b := range runtimeDurationBounds {
+			if upper <= b {
+				idx = j
+				break
+			}
+		}
+		counts[idx] += c
+		count += c
+		if lower > 0 && !math.IsInf(lower, 1) {
+			sum += lower * float64(c)
+		}
+	}
+
+	return metricdata.HistogramDataPoint[float64]{
+		Attributes:   attribute.NewSet(),
+		StartTime:    start,
+		Time:         now,
+		Count:        count,
+		Sum:          sum,
+		Bounds:       runtimeDurationBounds,
+		BucketCounts: counts,
+	}
+}
@@ -0,0 +1,138 @@
+package observability
+
+import (
+	"context"
+	"math"
+	"runtime"
+	"runtime/metrics"
+	"testing"
+	"time"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestRuntimeMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader(sdkmetric.WithProducer(newRuntimeProducer()))
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	defer func() { _ = mp.Shutdown(context.Background()) }()
+
+	if err := startRuntimeMetrics(mp); err != nil {
+		t.Fatalf("startRuntimeMetrics failed: %v", err)
+	}
+	runtime.GC()
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	got := map[string]metricdata.Aggregation{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			got[m.Name] = m.Data
+		}
+	}
+
+	for _, name := range []string{"go.goroutine.count", "go.memory.heap.alloc", "go.processor.limit"} {
+		gauge, ok := got[name].(metricdata.Gauge[int64])
+		if !ok || len(gauge.DataPoints) != 1 || gauge.DataPoints[0].Value <= 0 {
+			t.Errorf("expected a positive %s gauge, got %+v", name, got[name])
+		}
+	}
+	if gauge, ok := got["go.processor.limit"].(metricdata.Gauge[int64]); ok && gauge.DataPoints[0].Value != int64(runtime.GOMAXPROCS(0)) {
+		t.Errorf("expected go.processor.limit to equal GOMAXPROCS, got %d", gauge.DataPoints[0].Value)
+	}
+
+	for _, name := range []string{"go.gc.pause.duration", "go.schedule.duration"} {
+		hist, ok := got[name].(metricdata.Histogram[float64])
+		if !ok || len(hist.DataPoints) != 1 {
+			t.Errorf("expected a %s histogram, got %+v", name, got[name])
+			continue
+		}
+		if dp := hist.DataPoints[0]; len(dp.BucketCounts) != len(runtimeDurationBounds)+1 {
+			t.Errorf("expected %s to use the folded bounds, got %d buckets", name, len(dp.BucketCounts))
+		}
+	}
+	if hist, ok := got["go.gc.pause.duration"].(metricdata.Histogram[float64]); ok && hist.DataPoints[0].Count == 0 {
+		t.Error("expected GC pauses to be recorded after runtime.GC")
+	}
+	if hist, ok := got["go.gc.pause.duration"].(metricdata.Histogram[float64]); ok && hist.DataPoints[0].StartTime.After(initTime) {
+		t.Errorf("expected the histograms to start with the process, got %v", hist.DataPoints[0].StartTime)
+	}
+}
+
+func TestFoldRuntimeHistogram(t *testing.T) {
+	h := &metrics.Float64Histogram{
+		Buckets: []float64{math.Inf(-1), 0, 0.000002, 0.002, 2, math.Inf(1)},
+		Counts:  []uint64{1, 2, 3, 4, 5},
+	}
+	start := time.Now()
+
+	dp := foldRuntimeHistogram(h, start, start.Add(time.Second))
+
+	if dp.Count != 15 {
+		t.Errorf("expected count 15, got %d", dp.Count)
+	}
+	// -Inf..0 and 0..2µs fall in the first buckets, 2µs..2ms below 5ms, 2ms..2s and 2s..+Inf overflow
+	want := map[int]uint64{0: 1, 1: 2, 6: 3, 12: 9}
+	for i, c := range dp.BucketCounts {
+		if c != want[i] {
+			t.Errorf("bucket %d (le %v): expected %d, got %d", i, boundAt(i), want[i], c)
+		}
+	}
+	if wantSum := 3*0.000002 + 4*0.002 + 5*2.0; math.Abs(dp.Sum-wantSum) > 1e-9 {
+		t.Errorf("expected sum estimated from lower edges %v, got %v", wantSum, dp.Sum)
+	}
+}
+
+func TestNewObservability_RuntimeMetrics(t *testing.T) {
+	tests := []struct {
+		name     string
+		disabled bool
+		want     bool
+	}{
+		{name: "Enabled By Default", disabled: false, want: true},
+		{name: "Disabled", disabled: true, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o, err := NewObservability(BaseConfig{
+				ServiceName:            "test-otel-runtime",
+				Version:                "1.0.0",
+				TracingDisabled:        true,
+				MetricsMode:            "pull",
+				MetricsPath:            "/metrics",
+				MetricsPort:            0,
+				RuntimeMetricsDisabled: tt.disabled,
+				OtelDisableGlobals:     true,
+			})
+			if err != nil {
+				t.Fatalf("NewObservability failed: %v", err)
+			}
+			defer func() { _ = o.Shutdown(context.Background()) }()
+
+			families, err := o.Registry.Gather()
+			if err != nil {
+				t.Fatalf("gather failed: %v", err)
+			}
+			found := map[string]bool{}
+			for _, f := range families {
+				found[f.GetName()] = true
+			}
+			for _, name := range []string{"go_goroutine_count", "go_gc_pause_duration_seconds", "go_schedule_duration_seconds"} {
+				if found[name] != tt.want {
+					t.Errorf("expected %s present=%v in the scrape", name, tt.want)
+				}
+			}
+		})
+	}
+}
+
+// boundAt returns the upper bound of folded bucket i for failure messages
+func boundAt(i int) float64 {
+	if i < len(runtimeDurationBounds) {
+		return runtimeDurationBounds[i]
+	}
+	return math.Inf(1)
+}
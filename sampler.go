@@ -0,0 +1,188 @@
+package observability
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Sampler names follow the OTEL_TRACES_SAMPLER specification, plus rate limiting variants
+const (
+	SamplerAlwaysOn                = "always_on"
+	SamplerAlwaysOff               = "always_off"
+	SamplerTraceIDRatio            = "traceidratio"
+	SamplerParentBasedAlwaysOn     = "parentbased_always_on"
+	SamplerParentBasedAlwaysOff    = "parentbased_always_off"
+	SamplerParentBasedTraceIDRatio = "parentbased_traceidratio"
+	SamplerRateLimiting            = "ratelimiting"
+	SamplerParentBasedRateLimiting = "parentbased_ratelimiting"
+)
+
+// normalizeSampler lowercases the sampler name and maps empty to traceidratio for backward compatibility
+func normalizeSampler(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return SamplerTraceIDRatio
+	}
+	return name
+}
+
+// parseSamplerArg validates OTEL_TRACES_SAMPLER_ARG for the given sampler.
+// Ratio samplers fall back to fallbackRatio (OTEL_TRACING_SAMPLE_RATE) when arg is empty.
+func parseSamplerArg(sampler, arg string, fallbackRatio float64) (float64, error) {
+	arg = strings.TrimSpace(arg)
+
+	switch normalizeSampler(sampler) {
+	case SamplerAlwaysOn, SamplerAlwaysOff, SamplerParentBasedAlwaysOn, SamplerParentBasedAlwaysOff:
+		return 0, nil
+	case SamplerTraceIDRatio, SamplerParentBasedTraceIDRatio:
+		ratio := fallbackRatio
+		if arg != "" {
+			parsed, err := strconv.ParseFloat(arg, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid OTEL_TRACES_SAMPLER_ARG: %s (must be a number)", arg)
+			}
+			ratio = parsed
+		}
+		if ratio < 0 || ratio > 1 {
+			return 0, fmt.Errorf("invalid sampling ratio: %v (must be between 0 and 1)", ratio)
+		}
+		return ratio, nil
+	case SamplerRateLimiting, SamplerParentBasedRateLimiting:
+		if arg == "" {
+			return 0, fmt.Errorf("OTEL_TRACES_SAMPLER_ARG is required for %s sampler (traces per second)", sampler)
+		}
+		perSecond, err := strconv.ParseFloat(arg, 64)
+		if err != nil || perSecond <= 0 {
+			return 0, fmt.Errorf("invalid OTEL_TRACES_SAMPLER_ARG: %s (must be a positive number of traces per second)", arg)
+		}
+		return perSecond, nil
+	default:
+		return 0, fmt.Errorf("invalid OTEL_TRACES_SAMPLER: %s", sampler)
+	}
+}
+
+// tailSamplingRatio returns the ratio at which tail sampling keeps normal traces, resolved from
+// the configured head sampler. Rate limiting samplers cannot be applied to completed traces.
+func tailSamplingRatio(cfg BaseConfig) (float64, error) {
+	name := normalizeSampler(cfg.OtelTracesSampler)
+	switch name {
+	case SamplerAlwaysOn, SamplerParentBasedAlwaysOn:
+		return 1, nil
+	case SamplerAlwaysOff, SamplerParentBasedAlwaysOff:
+		return 0, nil
+	case SamplerTraceIDRatio, SamplerParentBasedTraceIDRatio:
+		return parseSamplerArg(name, cfg.OtelTracesSamplerArg, cfg.OtelTracingSampleRate)
+	case SamplerRateLimiting, SamplerParentBasedRateLimiting:
+		return 0, fmt.Errorf("invalid OTEL_TRACES_SAMPLER: %s (not supported with TAIL_SAMPLING_ENABLED, use a ratio sampler)", name)
+	default:
+		return 0, fmt.Errorf("invalid OTEL_TRACES_SAMPLER: %s", name)
+	}
+}
+
+// newSampler builds the trace sampler selected by the configuration
+func newSampler(cfg BaseConfig) (sdktrace.Sampler, error) {
+	name := normalizeSampler(cfg.OtelTracesSampler)
+	arg, err := parseSamplerArg(name, cfg.OtelTracesSamplerArg, cfg.OtelTracingSampleRate)
+	if err != nil {
+		return nil, err
+	}
+
+	switch name {
+	case SamplerAlwaysOn:
+		return sdktrace.AlwaysSample(), nil
+	case SamplerAlwaysOff:
+		return sdktrace.NeverSample(), nil
+	case SamplerTraceIDRatio:
+		return sdktrace.TraceIDRatioBased(arg), nil
+	case SamplerParentBasedAlwaysOn:
+		return sdktrace.ParentBased(sdktrace.AlwaysSample()), nil
+	case SamplerParentBasedAlwaysOff:
+		return sdktrace.ParentBased(sdktrace.NeverSample()), nil
+	case SamplerParentBasedTraceIDRatio:
+		return sdktrace.ParentBased(sdktrace.TraceIDRatioBased(arg)), nil
+	case SamplerRateLimiting:
+		return NewRateLimitingSampler(arg), nil
+	default: // SamplerParentBasedRateLimiting
+		return sdktrace.ParentBased(NewRateLimitingSampler(arg)), nil
+	}
+}
+
+// rateLimitingSampler samples at most maxPerSecond traces per second using a token bucket
+type rateLimitingSampler struct {
+	maxPerSecond float64
+
+	mu       sync.Mutex
+	balance  float64
+	lastTick time.Time
+	now      func() time.Time
+}
+
+// NewRateLimitingSampler returns a sampler that allows up to maxPerSecond new traces per second.
+// It allows short bursts of up to maxPerSecond (at least one) traces. Spans with a parent follow
+// the parent's sampled flag without consuming the budget.
+func NewRateLimitingSampler(maxPerSecond float64) sdktrace.Sampler {
+	return &rateLimitingSampler{
+		maxPerSecond: maxPerSecond,
+		balance:      maxBalance(maxPerSecond),
+		lastTick:     time.Now(),
+		now:          time.Now,
+	}
+}
+
+func maxBalance(maxPerSecond float64) float64 {
+	if maxPerSecond < 1 {
+		return 1
+	}
+	return maxPerSecond
+}
+
+// allow refills the bucket based on elapsed time and consumes a token if available
+func (s *rateLimitingSampler) allow() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	elapsed := now.Sub(s.lastTick).Seconds()
+	s.lastTick = now
+
+	s.balance += elapsed * s.maxPerSecond
+	if limit := maxBalance(s.maxPerSecond); s.balance > limit {
+		s.balance = limit
+	}
+
+	if s.balance >= 1 {
+		s.balance--
+		return true
+	}
+	return false
+}
+
+// ShouldSample implements sdktrace.Sampler
+func (s *rateLimitingSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	psc := trace.SpanContextFromContext(p.ParentContext)
+
+	// Spans of an existing trace follow its decision so the limit applies to traces, not spans
+	decision := sdktrace.Drop
+	if psc.IsValid() {
+		if psc.IsSampled() {
+			decision = sdktrace.RecordAndSample
+		}
+	} else if s.allow() {
+		decision = sdktrace.RecordAndSample
+	}
+	return sdktrace.SamplingResult{
+		Decision:   decision,
+		Tracestate: psc.TraceState(),
+	}
+}
+
+// Description implements sdktrace.Sampler
+func (s *rateLimitingSampler) Description() string {
+	return fmt.Sprintf("RateLimitingSampler{%g}", s.maxPerSecond)
+}
@@ -0,0 +1,217 @@
+package observability
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNewSampler(t *testing.T) {
+	tests := []struct {
+		name       string
+		sampler    string
+		arg        string
+		rate       float64
+		wantPrefix string
+		wantErr    bool
+	}{
+		{name: "Default Uses Sample Rate", sampler: "", rate: 0.5, wantPrefix: "TraceIDRatioBased{0.5}"},
+		{name: "Always On", sampler: "always_on", wantPrefix: "AlwaysOnSampler"},
+		{name: "Always Off", sampler: "ALWAYS_OFF", wantPrefix: "AlwaysOffSampler"},
+		{name: "Ratio From Arg", sampler: "traceidratio", arg: "0.25", rate: 1, wantPrefix: "TraceIDRatioBased{0.25}"},
+		{name: "Parent Based Ratio", sampler: "parentbased_traceidratio", arg: "0.1", wantPrefix: "ParentBased{root:TraceIDRatioBased{0.1}"},
+		{name: "Parent Based Always On", sampler: "parentbased_always_on", wantPrefix: "ParentBased{root:AlwaysOnSampler"},
+		{name: "Rate Limiting", sampler: "ratelimiting", arg: "5", wantPrefix: "RateLimitingSampler{5}"},
+		{name: "Parent Based Rate Limiting", sampler: "parentbased_ratelimiting", arg: "2", wantPrefix: "ParentBased{root:RateLimitingSampler{2}"},
+		{name: "Ratio Out Of Range", sampler: "traceidratio", arg: "1.5", wantErr: true},
+		{name: "Ratio Not A Number", sampler: "traceidratio", arg: "half", wantErr: true},
+		{name: "Rate Limiting Without Arg", sampler: "ratelimiting", wantErr: true},
+		{name: "Rate Limiting Negative", sampler: "ratelimiting", arg: "-1", wantErr: true},
+		{name: "Unknown Sampler", sampler: "jaeger_remote", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := newSampler(BaseConfig{
+				OtelTracesSampler:     tt.sampler,
+				OtelTracesSamplerArg:  tt.arg,
+				OtelTracingSampleRate: tt.rate,
+			})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got sampler %s", s.Description())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !strings.HasPrefix(s.Description(), tt.wantPrefix) {
+				t.Errorf("expected description starting with %q, got %q", tt.wantPrefix, s.Description())
+			}
+		})
+	}
+}
+
+func TestRateLimitingSampler(t *testing.T) {
+	current := time.Unix(1000, 0)
+	s := NewRateLimitingSampler(2).(*rateLimitingSampler)
+	s.now = func() time.Time { return current }
+	s.lastTick = current
+
+	params := sdktrace.SamplingParameters{ParentContext: context.Background(), TraceID: trace.TraceID{1}}
+
+	sampled := 0
+	for i := 0; i < 10; i++ {
+		if s.ShouldSample(params).Decision == sdktrace.RecordAndSample {
+			sampled++
+		}
+	}
+	if sampled != 2 {
+		t.Errorf("expected burst of 2 sampled traces, got %d", sampled)
+	}
+
+	// Half a second refills one token
+	current = current.Add(500 * time.Millisecond)
+	if s.ShouldSample(params).Decision != sdktrace.RecordAndSample {
+		t.Error("expected a trace to be sampled after refill")
+	}
+	if s.ShouldSample(params).Decision != sdktrace.Drop {
+		t.Error("expected trace to be dropped once tokens are exhausted")
+	}
+}
+
+func TestRateLimitingSamplerKeepsTracesWhole(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(NewRateLimitingSampler(1)),
+		sdktrace.WithSpanProcessor(sr),
+	)
+	defer func() { _ = tp.Shutdown(context.Background()) }()
+	tracer := tp.Tracer("test")
+
+	ctx, root := tracer.Start(context.Background(), "root")
+	_, child := tracer.Start(ctx, "child")
+	child.End()
+	root.End()
+
+	// The budget is spent on the first root only
+	_, dropped := tracer.Start(context.Background(), "second-root")
+	dropped.End()
+
+	var names []string
+	for _, span := range sr.Ended() {
+		if span.SpanContext().IsSampled() {
+			names = append(names, span.Name())
+		}
+	}
+	if len(names) != 2 || names[0] != "child" || names[1] != "root" {
+		t.Errorf("expected the root and its child to be sampled, got %v", names)
+	}
+}
+
+func TestParentBasedSamplerRespectsParent(t *testing.T) {
+	s, err := newSampler(BaseConfig{OtelTracesSampler: "parentbased_traceidratio", OtelTracesSamplerArg: "0"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	parent := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{1},
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+	ctx := trace.ContextWithRemoteSpanContext(context.Background(), parent)
+
+	result := s.ShouldSample(sdktrace.SamplingParameters{ParentContext: ctx, TraceID: parent.TraceID()})
+	if result.Decision != sdktrace.RecordAndSample {
+		t.Errorf("expected sampled parent to be honored with ratio 0, got %v", result.Decision)
+	}
+}
+
+func TestLoadCfgSamplerValidation(t *testing.T) {
+	defer func() {
+		_ = os.Unsetenv("OTEL_TRACES_SAMPLER")
+		_ = os.Unsetenv("OTEL_TRACES_SAMPLER_ARG")
+		_ = os.Unsetenv("OTEL_TRACING_SAMPLE_RATE")
+	}()
+
+	_ = os.Unsetenv("LOG_LEVEL")
+	_ = os.Setenv("SERVICE_NAME", "sampler-service")
+	_ = os.Setenv("METRICS_MODE", "pull")
+
+	t.Run("Default Sampler", func(t *testing.T) {
+		var cfg BaseConfig
+		if err := LoadCfg(&cfg); err != nil {
+			t.Fatalf("LoadCfg failed: %v", err)
+		}
+		if cfg.OtelTracesSampler != "traceidratio" {
+			t.Errorf("expected default sampler 'traceidratio', got '%s'", cfg.OtelTracesSampler)
+		}
+	})
+
+	t.Run("Parent Based Ratio", func(t *testing.T) {
+		_ = os.Setenv("OTEL_TRACES_SAMPLER", "parentbased_traceidratio")
+		_ = os.Setenv("OTEL_TRACES_SAMPLER_ARG", "0.2")
+
+		var cfg BaseConfig
+		if err := LoadCfg(&cfg); err != nil {
+			t.Fatalf("LoadCfg failed: %v", err)
+		}
+		if cfg.OtelTracesSamplerArg != "0.2" {
+			t.Errorf("expected sampler arg '0.2', got '%s'", cfg.OtelTracesSamplerArg)
+		}
+	})
+
+	t.Run("Ratio Out Of Range", func(t *testing.T) {
+		_ = os.Setenv("OTEL_TRACES_SAMPLER", "traceidratio")
+		_ = os.Setenv("OTEL_TRACES_SAMPLER_ARG", "2")
+
+		var cfg BaseConfig
+		if err := LoadCfg(&cfg); err == nil {
+			t.Error("expected LoadCfg to fail for sampler ratio 2")
+		}
+	})
+
+	t.Run("Sample Rate Out Of Range", func(t *testing.T) {
+		_ = os.Unsetenv("OTEL_TRACES_SAMPLER")
+		_ = os.Unsetenv("OTEL_TRACES_SAMPLER_ARG")
+		_ = os.Setenv("OTEL_TRACING_SAMPLE_RATE", "-0.1")
+
+		var cfg BaseConfig
+		if err := LoadCfg(&cfg); err == nil {
+			t.Error("expected LoadCfg to fail for OTEL_TRACING_SAMPLE_RATE -0.1")
+		}
+	})
+
+	t.Run("Rate Limiting With Tail Sampling", func(t *testing.T) {
+		_ = os.Unsetenv("OTEL_TRACING_SAMPLE_RATE")
+		_ = os.Setenv("OTEL_TRACES_SAMPLER", "ratelimiting")
+		_ = os.Setenv("OTEL_TRACES_SAMPLER_ARG", "5")
+		_ = os.Setenv("TAIL_SAMPLING_ENABLED", "true")
+		defer func() { _ = os.Unsetenv("TAIL_SAMPLING_ENABLED") }()
+
+		var cfg BaseConfig
+		if err := LoadCfg(&cfg); err == nil {
+			t.Error("expected LoadCfg to reject a rate limiting sampler with tail sampling")
+		}
+	})
+
+	t.Run("Unknown Sampler", func(t *testing.T) {
+		_ = os.Unsetenv("OTEL_TRACING_SAMPLE_RATE")
+		_ = os.Unsetenv("OTEL_TRACES_SAMPLER_ARG")
+		_ = os.Setenv("OTEL_TRACES_SAMPLER", "jaeger_remote")
+
+		var cfg BaseConfig
+		if err := LoadCfg(&cfg); err == nil {
+			t.Error("expected LoadCfg to fail for unknown OTEL_TRACES_SAMPLER")
+		}
+	})
+}
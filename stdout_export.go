@@ -0,0 +1,93 @@
+package observability
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Trace export targets accepted by TRACES_EXPORT
+const (
+	TracesExportOTLP   = "otlp"
+	TracesExportStdout = "stdout"
+)
+
+// Destinations accepted by STDOUT_EXPORT_OUTPUT
+const (
+	StdoutOutputStderr = "stderr"
+	StdoutOutputLogger = "logger"
+)
+
+// isStdoutTraces returns true if spans are printed instead of exported over OTLP
+func (b *BaseConfig) isStdoutTraces() bool {
+	return strings.ToLower(strings.TrimSpace(b.TracesExport)) == TracesExportStdout
+}
+
+// IsStdoutMetrics returns true if MetricsMode is "stdout"
+func (b *BaseConfig) IsStdoutMetrics() bool {
+	mode := strings.ToLower(strings.TrimSpace(b.MetricsMode))
+	return mode == "stdout"
+}
+
+// isLoggerOutput returns true if stdout exporters write through the Logger instead of stderr
+func (b *BaseConfig) isLoggerOutput() bool {
+	return strings.ToLower(strings.TrimSpace(b.StdoutExportOutput)) == StdoutOutputLogger
+}
+
+// stdoutWriter returns the destination of the stdout exporters for a signal
+func stdoutWriter(cfg BaseConfig, logger *Logger, signal string) io.Writer {
+	if cfg.isLoggerOutput() && logger != nil {
+		return &loggerWriter{logger: logger, signal: signal}
+	}
+	return os.Stderr
+}
+
+// newStdoutTraceExporter creates a span exporter printing spans as JSON, indented unless the
+// output goes through the Logger
+func newStdoutTraceExporter(cfg BaseConfig, w io.Writer) (sdktrace.SpanExporter, error) {
+	opts := []stdouttrace.Option{stdouttrace.WithWriter(w)}
+	if !cfg.isLoggerOutput() {
+		opts = append(opts, stdouttrace.WithPrettyPrint())
+	}
+	exp, err := stdouttrace.New(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stdout trace exporter: %w", err)
+	}
+	return exp, nil
+}
+
+// newStdoutMetricReader creates a periodic reader printing a metrics snapshot every
+// MetricsPushInterval seconds
+func newStdoutMetricReader(cfg BaseConfig, w io.Writer, pipeline metricPipeline) (*sdkmetric.PeriodicReader, error) {
+	opts := []stdoutmetric.Option{stdoutmetric.WithWriter(w)}
+	if !cfg.isLoggerOutput() {
+		opts = append(opts, stdoutmetric.WithPrettyPrint())
+	}
+	if selector := cfg.histogramAggregationSelector(); selector != nil {
+		opts = append(opts, stdoutmetric.WithAggregationSelector(selector))
+	}
+	exp, err := stdoutmetric.New(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stdout metrics exporter: %w", err)
+	}
+	return pipeline.periodicReader(exp, time.Duration(cfg.MetricsPushInterval)*time.Second), nil
+}
+
+// loggerWriter logs every JSON document written by a stdout exporter as one log entry
+type loggerWriter struct {
+	logger *Logger
+	signal string
+}
+
+// Write logs p at info level. The stdout exporters write each span or snapshot in a single call.
+func (w *loggerWriter) Write(p []byte) (int, error) {
+	w.logger.Info("Telemetry export", "signal", w.signal, "data", strings.TrimSpace(string(p)))
+	return len(p), nil
+}
@@ -0,0 +1,154 @@
+package observability
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestStdoutTraceExporter(t *testing.T) {
+	var buf bytes.Buffer
+	exp, err := newStdoutTraceExporter(BaseConfig{}, &buf)
+	if err != nil {
+		t.Fatalf("newStdoutTraceExporter failed: %v", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp))
+	_, span := tp.Tracer("stdout-test").Start(context.Background(), "checkout")
+	span.End()
+	_ = tp.Shutdown(context.Background())
+
+	out := buf.String()
+	if !strings.Contains(out, `"Name": "checkout"`) {
+		t.Errorf("expected pretty printed span in output, got %q", out)
+	}
+}
+
+func TestStdoutMetricReader(t *testing.T) {
+	var buf bytes.Buffer
+	reader, err := newStdoutMetricReader(BaseConfig{MetricsPushInterval: 30}, &buf, metricPipeline{})
+	if err != nil {
+		t.Fatalf("newStdoutMetricReader failed: %v", err)
+	}
+
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	counter, err := mp.Meter("stdout-test").Int64Counter("orders_total")
+	if err != nil {
+		t.Fatalf("failed to create counter: %v", err)
+	}
+	counter.Add(context.Background(), 3)
+
+	// Shutdown prints a final snapshot
+	if err := reader.Shutdown(context.Background()); err != nil {
+		t.Fatalf("reader shutdown failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "orders_total") {
+		t.Errorf("expected metrics snapshot in output, got %q", buf.String())
+	}
+}
+
+func TestStdoutLoggerOutput(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	logger := &Logger{SugaredLogger: zap.New(core).Sugar()}
+
+	cfg := BaseConfig{StdoutExportOutput: "logger"}
+	exp, err := newStdoutTraceExporter(cfg, stdoutWriter(cfg, logger, "traces"))
+	if err != nil {
+		t.Fatalf("newStdoutTraceExporter failed: %v", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp))
+	_, span := tp.Tracer("stdout-test").Start(context.Background(), "logged-span")
+	span.End()
+	_ = tp.Shutdown(context.Background())
+
+	entries := logs.FilterMessage("Telemetry export").All()
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 log entry, got %d", len(entries))
+	}
+	fields := entries[0].ContextMap()
+	data, _ := fields["data"].(string)
+	if fields["signal"] != "traces" || !strings.Contains(data, `"Name":"logged-span"`) || strings.Contains(data, "\n") {
+		t.Errorf("expected compact span JSON in the log entry, got %v", fields)
+	}
+}
+
+func TestNewObservabilityStdout(t *testing.T) {
+	cfg := BaseConfig{
+		ServiceName:           "test-otel-stdout",
+		Version:               "1.0.0",
+		TracesExport:          "stdout",
+		OtelTracingSampleRate: 1.0,
+		MetricsMode:           "stdout",
+		MetricsPushInterval:   30,
+		MetricsPath:           "/metrics",
+		OtelDisableGlobals:    true,
+	}
+
+	o, err := NewObservability(cfg)
+	if err != nil {
+		t.Fatalf("NewObservability in stdout mode failed: %v", err)
+	}
+	if o.MetricsAddr() != "" || o.Registry != nil {
+		t.Error("expected no metrics server in stdout mode")
+	}
+
+	_, span := o.Tracer("stdout").Start(context.Background(), "offline")
+	span.End()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := o.ForceFlush(ctx); err != nil {
+		t.Errorf("expected stdout exporters to flush without a collector, got %v", err)
+	}
+	_ = o.Shutdown(ctx)
+}
+
+func TestLoadCfgStdoutExport(t *testing.T) {
+	defer func() {
+		_ = os.Unsetenv("TRACES_EXPORT")
+		_ = os.Unsetenv("STDOUT_EXPORT_OUTPUT")
+		_ = os.Setenv("METRICS_MODE", "pull")
+	}()
+
+	_ = os.Unsetenv("LOG_LEVEL")
+	_ = os.Setenv("SERVICE_NAME", "stdout-export-service")
+
+	tests := []struct {
+		name    string
+		traces  string
+		metrics string
+		output  string
+		wantErr bool
+	}{
+		{name: "Stdout Traces And Metrics", traces: "stdout", metrics: "stdout", output: "", wantErr: false},
+		{name: "Logger Output", traces: "stdout", metrics: "pull", output: "logger", wantErr: false},
+		{name: "Invalid Traces Export", traces: "console", metrics: "pull", output: "", wantErr: true},
+		{name: "Invalid Output", traces: "stdout", metrics: "pull", output: "file", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_ = os.Unsetenv("STDOUT_EXPORT_OUTPUT")
+			_ = os.Setenv("TRACES_EXPORT", tt.traces)
+			_ = os.Setenv("METRICS_MODE", tt.metrics)
+			if tt.output != "" {
+				_ = os.Setenv("STDOUT_EXPORT_OUTPUT", tt.output)
+			}
+
+			var cfg BaseConfig
+			err := LoadCfg(&cfg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoadCfg() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,241 @@
+package observability
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Defaults applied by NewTailSamplingProcessor when limits are not set
+const (
+	defaultTailSamplingMaxTraces        = 10000
+	defaultTailSamplingMaxSpansPerTrace = 1000
+)
+
+// TailSamplingConfig configures the in-process tail-based sampling span processor
+type TailSamplingConfig struct {
+	// LatencyThreshold keeps traces containing a span at least this long. Zero disables the rule.
+	LatencyThreshold time.Duration
+	// Ratio is the fraction of remaining traces to keep, decided by trace ID
+	Ratio float64
+	// MaxTraces bounds the number of traces buffered at once. When full, the oldest trace is
+	// decided early with the spans seen so far.
+	MaxTraces int
+	// MaxSpansPerTrace bounds the spans buffered per trace; extra spans are dropped
+	MaxSpansPerTrace int
+	// MeterProvider is used for the kept/dropped counters. Defaults to a no-op provider.
+	MeterProvider metric.MeterProvider
+}
+
+// tailTrace holds the buffered spans of one local trace
+type tailTrace struct {
+	id      trace.TraceID
+	active  int
+	spans   []sdktrace.ReadOnlySpan
+	element *list.Element
+}
+
+// TailSamplingProcessor buffers spans per local trace and forwards whole traces to the next
+// processor once all their local spans have ended. Traces with an error span or a span over the
+// latency threshold are always kept; others are kept at the configured ratio.
+type TailSamplingProcessor struct {
+	next    sdktrace.SpanProcessor
+	cfg     TailSamplingConfig
+	sampler sdktrace.Sampler
+
+	mu     sync.Mutex
+	traces map[trace.TraceID]*tailTrace
+	order  *list.List
+
+	kept         metric.Int64Counter
+	dropped      metric.Int64Counter
+	droppedSpans metric.Int64Counter
+}
+
+var _ sdktrace.SpanProcessor = (*TailSamplingProcessor)(nil)
+
+// NewTailSamplingProcessor returns a tail sampling processor forwarding kept traces to next
+func NewTailSamplingProcessor(next sdktrace.SpanProcessor, cfg TailSamplingConfig) *TailSamplingProcessor {
+	if cfg.MaxTraces <= 0 {
+		cfg.MaxTraces = defaultTailSamplingMaxTraces
+	}
+	if cfg.MaxSpansPerTrace <= 0 {
+		cfg.MaxSpansPerTrace = defaultTailSamplingMaxSpansPerTrace
+	}
+	if cfg.MeterProvider == nil {
+		cfg.MeterProvider = noop.NewMeterProvider()
+	}
+
+	meter := cfg.MeterProvider.Meter("tail-sampling")
+	kept, err := meter.Int64Counter("tail_sampling.traces.kept",
+		metric.WithDescription("Number of traces kept by tail sampling"),
+	)
+	if err != nil {
+		kept = noop.Int64Counter{}
+	}
+	dropped, err := meter.Int64Counter("tail_sampling.traces.dropped",
+		metric.WithDescription("Number of traces dropped by tail sampling"),
+	)
+	if err != nil {
+		dropped = noop.Int64Counter{}
+	}
+	droppedSpans, err := meter.Int64Counter("tail_sampling.spans.dropped",
+		metric.WithDescription("Number of spans dropped because a trace exceeded the per-trace buffer"),
+	)
+	if err != nil {
+		droppedSpans = noop.Int64Counter{}
+	}
+
+	return &TailSamplingProcessor{
+		next:         next,
+		cfg:          cfg,
+		sampler:      sdktrace.TraceIDRatioBased(cfg.Ratio),
+		traces:       make(map[trace.TraceID]*tailTrace),
+		order:        list.New(),
+		kept:         kept,
+		dropped:      dropped,
+		droppedSpans: droppedSpans,
+	}
+}
+
+// OnStart registers the span as active in its local trace
+func (p *TailSamplingProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	var evicted *tailTrace
+
+	p.mu.Lock()
+	id := s.SpanContext().TraceID()
+	t, ok := p.traces[id]
+	if !ok {
+		// Make room by deciding the oldest buffered trace early
+		if len(p.traces) >= p.cfg.MaxTraces {
+			if front := p.order.Front(); front != nil {
+				evicted = front.Value.(*tailTrace)
+				p.remove(evicted)
+			}
+		}
+		t = &tailTrace{id: id}
+		t.element = p.order.PushBack(t)
+		p.traces[id] = t
+	}
+	t.active++
+	p.mu.Unlock()
+
+	if evicted != nil {
+		p.decide(evicted.id, evicted.spans)
+	}
+	p.next.OnStart(parent, s)
+}
+
+// OnEnd buffers the span and decides the trace once all its local spans have ended
+func (p *TailSamplingProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	id := s.SpanContext().TraceID()
+
+	p.mu.Lock()
+	t, ok := p.traces[id]
+	if !ok {
+		// The trace was evicted early: decide this late span on its own
+		p.mu.Unlock()
+		p.decide(id, []sdktrace.ReadOnlySpan{s})
+		return
+	}
+
+	if len(t.spans) < p.cfg.MaxSpansPerTrace {
+		t.spans = append(t.spans, s)
+	} else {
+		p.droppedSpans.Add(context.Background(), 1)
+	}
+
+	t.active--
+	if t.active > 0 {
+		p.mu.Unlock()
+		return
+	}
+	p.remove(t)
+	p.mu.Unlock()
+
+	p.decide(id, t.spans)
+}
+
+// remove deletes a trace from the buffer. Callers must hold p.mu.
+func (p *TailSamplingProcessor) remove(t *tailTrace) {
+	delete(p.traces, t.id)
+	p.order.Remove(t.element)
+}
+
+// decide forwards the spans of a trace to the next processor if the trace is kept
+func (p *TailSamplingProcessor) decide(id trace.TraceID, spans []sdktrace.ReadOnlySpan) {
+	if len(spans) == 0 {
+		return
+	}
+
+	reason := p.keepReason(id, spans)
+	if reason == "" {
+		p.dropped.Add(context.Background(), 1)
+		return
+	}
+
+	p.kept.Add(context.Background(), 1, metric.WithAttributes(attribute.String("reason", reason)))
+	for _, s := range spans {
+		p.next.OnEnd(s)
+	}
+}
+
+// keepReason returns why a trace is kept ("error", "latency", "ratio") or "" to drop it
+func (p *TailSamplingProcessor) keepReason(id trace.TraceID, spans []sdktrace.ReadOnlySpan) string {
+	slow := false
+	for _, s := range spans {
+		if s.Status().Code == codes.Error {
+			return "error"
+		}
+		if p.cfg.LatencyThreshold > 0 && s.EndTime().Sub(s.StartTime()) >= p.cfg.LatencyThreshold {
+			slow = true
+		}
+	}
+	if slow {
+		return "latency"
+	}
+
+	result := p.sampler.ShouldSample(sdktrace.SamplingParameters{
+		ParentContext: context.Background(),
+		TraceID:       id,
+	})
+	if result.Decision == sdktrace.RecordAndSample {
+		return "ratio"
+	}
+	return ""
+}
+
+// flushAll decides every buffered trace with the spans seen so far
+func (p *TailSamplingProcessor) flushAll() {
+	p.mu.Lock()
+	pending := make([]*tailTrace, 0, len(p.traces))
+	for e := p.order.Front(); e != nil; e = e.Next() {
+		pending = append(pending, e.Value.(*tailTrace))
+	}
+	p.traces = make(map[trace.TraceID]*tailTrace)
+	p.order.Init()
+	p.mu.Unlock()
+
+	for _, t := range pending {
+		p.decide(t.id, t.spans)
+	}
+}
+
+// Shutdown decides all buffered traces and shuts down the next processor
+func (p *TailSamplingProcessor) Shutdown(ctx context.Context) error {
+	p.flushAll()
+	return p.next.Shutdown(ctx)
+}
+
+// ForceFlush flushes the next processor. Traces with spans still running stay buffered.
+func (p *TailSamplingProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
@@ -0,0 +1,253 @@
+package observability
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// newTailSamplingTest returns a tracer whose spans go through a tail sampling processor into a recorder
+func newTailSamplingTest(t *testing.T, cfg TailSamplingConfig) (trace.Tracer, *tracetest.SpanRecorder, *sdkmetric.ManualReader) {
+	t.Helper()
+
+	reader := sdkmetric.NewManualReader()
+	cfg.MeterProvider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(sdktrace.AlwaysSample()),
+		sdktrace.WithSpanProcessor(NewTailSamplingProcessor(sr, cfg)),
+	)
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	return tp.Tracer("tail-sampling-test"), sr, reader
+}
+
+// counterValue sums an Int64 counter's data points whose attributes contain the given key/value
+func counterValue(t *testing.T, reader *sdkmetric.ManualReader, name, attrKey, attrValue string) int64 {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+
+	var total int64
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != name {
+				continue
+			}
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			if !ok {
+				t.Fatalf("metric %s is not an int64 sum: %T", name, m.Data)
+			}
+			for _, dp := range sum.DataPoints {
+				if attrKey != "" {
+					if v, ok := dp.Attributes.Value(attribute.Key(attrKey)); !ok || v.AsString() != attrValue {
+						continue
+					}
+				}
+				total += dp.Value
+			}
+		}
+	}
+	return total
+}
+
+func TestTailSamplingKeepsErrorTraces(t *testing.T) {
+	tracer, sr, reader := newTailSamplingTest(t, TailSamplingConfig{Ratio: 0})
+
+	ctx, root := tracer.Start(context.Background(), "root")
+	_, child := tracer.Start(ctx, "child")
+	child.SetStatus(codes.Error, "failed")
+	child.End()
+
+	if len(sr.Ended()) != 0 {
+		t.Fatal("expected spans to be buffered until the local trace completes")
+	}
+	root.End()
+
+	if got := len(sr.Ended()); got != 2 {
+		t.Fatalf("expected the whole error trace (2 spans) to be kept, got %d", got)
+	}
+	if got := counterValue(t, reader, "tail_sampling.traces.kept", "reason", "error"); got != 1 {
+		t.Errorf("expected 1 trace kept for error, got %d", got)
+	}
+}
+
+func TestTailSamplingKeepsSlowTraces(t *testing.T) {
+	tracer, sr, reader := newTailSamplingTest(t, TailSamplingConfig{Ratio: 0, LatencyThreshold: 50 * time.Millisecond})
+
+	start := time.Now()
+	_, span := tracer.Start(context.Background(), "slow", trace.WithTimestamp(start))
+	span.End(trace.WithTimestamp(start.Add(100 * time.Millisecond)))
+
+	if got := len(sr.Ended()); got != 1 {
+		t.Fatalf("expected slow trace to be kept, got %d spans", got)
+	}
+	if got := counterValue(t, reader, "tail_sampling.traces.kept", "reason", "latency"); got != 1 {
+		t.Errorf("expected 1 trace kept for latency, got %d", got)
+	}
+}
+
+func TestTailSamplingAppliesRatio(t *testing.T) {
+	t.Run("Ratio 0 Drops", func(t *testing.T) {
+		tracer, sr, reader := newTailSamplingTest(t, TailSamplingConfig{Ratio: 0, LatencyThreshold: time.Hour})
+
+		_, span := tracer.Start(context.Background(), "fast")
+		span.End()
+
+		if got := len(sr.Ended()); got != 0 {
+			t.Fatalf("expected trace to be dropped, got %d spans", got)
+		}
+		if got := counterValue(t, reader, "tail_sampling.traces.dropped", "", ""); got != 1 {
+			t.Errorf("expected 1 dropped trace, got %d", got)
+		}
+	})
+
+	t.Run("Ratio 1 Keeps", func(t *testing.T) {
+		tracer, sr, reader := newTailSamplingTest(t, TailSamplingConfig{Ratio: 1})
+
+		_, span := tracer.Start(context.Background(), "fast")
+		span.End()
+
+		if got := len(sr.Ended()); got != 1 {
+			t.Fatalf("expected trace to be kept, got %d spans", got)
+		}
+		if got := counterValue(t, reader, "tail_sampling.traces.kept", "reason", "ratio"); got != 1 {
+			t.Errorf("expected 1 trace kept by ratio, got %d", got)
+		}
+	})
+}
+
+func TestTailSamplingBoundsMemory(t *testing.T) {
+	tracer, sr, _ := newTailSamplingTest(t, TailSamplingConfig{Ratio: 0, MaxTraces: 2, MaxSpansPerTrace: 2})
+
+	// Three concurrent traces with a limit of two: the first is evicted and decided early
+	ctx1, root1 := tracer.Start(context.Background(), "root-1")
+	_, failed := tracer.Start(ctx1, "failed")
+	failed.SetStatus(codes.Error, "boom")
+	failed.End()
+
+	_, root2 := tracer.Start(context.Background(), "root-2")
+	_, root3 := tracer.Start(context.Background(), "root-3")
+
+	if got := len(sr.Ended()); got != 1 {
+		t.Fatalf("expected evicted error trace to be exported early, got %d spans", got)
+	}
+
+	root1.End()
+	root2.End()
+	root3.End()
+
+	// Late span of the evicted trace has no error and ratio 0: dropped
+	if got := len(sr.Ended()); got != 1 {
+		t.Errorf("expected only the error span to be exported, got %d spans", got)
+	}
+
+	// Spans beyond MaxSpansPerTrace are dropped from the buffer
+	ctx, root := tracer.Start(context.Background(), "root")
+	for i := 0; i < 3; i++ {
+		_, child := tracer.Start(ctx, "child")
+		child.SetStatus(codes.Error, "boom")
+		child.End()
+	}
+	root.End()
+
+	if got := len(sr.Ended()); got != 3 {
+		t.Errorf("expected 2 more spans (per-trace limit), got %d total", got)
+	}
+}
+
+func TestTailSamplingShutdownFlushesPendingTraces(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	p := NewTailSamplingProcessor(sr, TailSamplingConfig{Ratio: 1})
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(p))
+	tracer := tp.Tracer("tail-sampling-test")
+
+	ctx, root := tracer.Start(context.Background(), "root")
+	_, child := tracer.Start(ctx, "child")
+	child.End()
+
+	if err := tp.Shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown failed: %v", err)
+	}
+	root.End()
+
+	if got := len(sr.Ended()); got != 1 {
+		t.Errorf("expected buffered child span to be flushed on shutdown, got %d", got)
+	}
+}
+
+func TestInitOtelWithTailSampling(t *testing.T) {
+	cfg := BaseConfig{
+		ServiceName:           "test-otel-tail",
+		Version:               "1.0.0",
+		OtelEndpoint:          "localhost:4318",
+		OtelInsecure:          true,
+		OtelTracingSampleRate: 0.1,
+		TailSamplingEnabled:   true,
+		TailSamplingLatencyMs: 500,
+		MetricsPort:           19098,
+		MetricsMode:           "pull",
+		MetricsPath:           "/metrics",
+	}
+
+	shutdown, err := InitOtel(cfg)
+	if err != nil {
+		t.Fatalf("InitOtel with tail sampling failed: %v", err)
+	}
+
+	_, span := GetTracer("test-tail").Start(context.Background(), "op")
+	if !span.SpanContext().IsSampled() {
+		t.Error("expected head sampling to record every span when tail sampling is enabled")
+	}
+	span.End()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	_ = shutdown(ctx) // Ignore error as collector may not be running
+}
+
+func TestTailSamplingRatio(t *testing.T) {
+	tests := []struct {
+		name    string
+		sampler string
+		arg     string
+		rate    float64
+		want    float64
+		wantErr bool
+	}{
+		{name: "Ratio From Arg", sampler: "traceidratio", arg: "0.05", rate: 1, want: 0.05},
+		{name: "Parent Based Ratio", sampler: "parentbased_traceidratio", arg: "0.2", rate: 1, want: 0.2},
+		{name: "Sample Rate Fallback", sampler: "", rate: 0.1, want: 0.1},
+		{name: "Always On", sampler: "always_on", rate: 0.1, want: 1},
+		{name: "Always Off", sampler: "parentbased_always_off", rate: 1, want: 0},
+		{name: "Rate Limiting", sampler: "ratelimiting", arg: "5", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tailSamplingRatio(BaseConfig{
+				OtelTracesSampler:     tt.sampler,
+				OtelTracesSamplerArg:  tt.arg,
+				OtelTracingSampleRate: tt.rate,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("tailSamplingRatio() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("expected ratio %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
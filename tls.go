@@ -0,0 +1,137 @@
+package observability
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// tlsFiles describes the TLS material used to connect to an OTLP endpoint
+type tlsFiles struct {
+	CAFile     string
+	CertFile   string
+	KeyFile    string
+	ServerName string
+}
+
+// isSet returns true if any TLS setting is configured
+func (f tlsFiles) isSet() bool {
+	return strings.TrimSpace(f.CAFile) != "" ||
+		strings.TrimSpace(f.CertFile) != "" ||
+		strings.TrimSpace(f.KeyFile) != "" ||
+		strings.TrimSpace(f.ServerName) != ""
+}
+
+// traceTLS returns the TLS settings for the trace exporter
+func (b *BaseConfig) traceTLS() tlsFiles {
+	return tlsFiles{
+		CAFile:     b.OtelCACert,
+		CertFile:   b.OtelClientCert,
+		KeyFile:    b.OtelClientKey,
+		ServerName: b.OtelTLSServerName,
+	}
+}
+
+// metricsTLS returns the TLS settings for the metrics push exporter
+func (b *BaseConfig) metricsTLS() tlsFiles {
+	return tlsFiles{
+		CAFile:     b.MetricsCACert,
+		CertFile:   b.MetricsClientCert,
+		KeyFile:    b.MetricsClientKey,
+		ServerName: b.MetricsTLSServerName,
+	}
+}
+
+// metricsServerTLS returns the TLS settings of the pull metrics server. CAFile verifies clients.
+func (b *BaseConfig) metricsServerTLS() tlsFiles {
+	return tlsFiles{
+		CAFile:   b.MetricsServerClientCA,
+		CertFile: b.MetricsServerCert,
+		KeyFile:  b.MetricsServerKey,
+	}
+}
+
+// newTLSConfig builds a tls.Config from the configured files.
+// Returns nil when no TLS setting is configured so exporters keep their defaults.
+func newTLSConfig(files tlsFiles) (*tls.Config, error) {
+	if !files.isSet() {
+		return nil, nil
+	}
+
+	tlsCfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: strings.TrimSpace(files.ServerName),
+	}
+
+	// Private CA bundle used to verify the collector certificate
+	if ca := strings.TrimSpace(files.CAFile); ca != "" {
+		pool, err := loadCertPool(ca)
+		if err != nil {
+			return nil, err
+		}
+		tlsCfg.RootCAs = pool
+	}
+
+	// Client certificate for mTLS
+	cert, key := strings.TrimSpace(files.CertFile), strings.TrimSpace(files.KeyFile)
+	if cert != "" || key != "" {
+		if cert == "" || key == "" {
+			return nil, fmt.Errorf("client certificate and key must be set together")
+		}
+		pair, err := tls.LoadX509KeyPair(cert, key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate %s and key %s: %w", cert, key, err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{pair}
+	}
+
+	return tlsCfg, nil
+}
+
+// newServerTLSConfig builds the tls.Config of a server from its certificate and key. A CA file
+// turns on mTLS: clients must present a certificate it signed.
+// Returns nil when no TLS setting is configured so the server keeps plain HTTP.
+func newServerTLSConfig(files tlsFiles) (*tls.Config, error) {
+	if !files.isSet() {
+		return nil, nil
+	}
+
+	cert, key := strings.TrimSpace(files.CertFile), strings.TrimSpace(files.KeyFile)
+	if cert == "" || key == "" {
+		return nil, fmt.Errorf("server certificate and key are required")
+	}
+	pair, err := tls.LoadX509KeyPair(cert, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server certificate %s and key %s: %w", cert, key, err)
+	}
+	tlsCfg := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{pair},
+	}
+
+	if ca := strings.TrimSpace(files.CAFile); ca != "" {
+		pool, err := loadCertPool(ca)
+		if err != nil {
+			return nil, err
+		}
+		tlsCfg.ClientCAs = pool
+		tlsCfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return tlsCfg, nil
+}
+
+// loadCertPool reads a PEM CA bundle
+func loadCertPool(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA file %s: %w", path, err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no valid certificates found in CA file %s", path)
+	}
+	return pool, nil
+}
@@ -0,0 +1,326 @@
+package observability
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeTestCert writes a self-signed certificate and key into dir and returns their paths.
+// The certificate doubles as its own CA for tests.
+func writeTestCert(t *testing.T, dir string) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "otel-collector.test"},
+		DNSNames:              []string{"otel-collector.test", "localhost"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+
+	certPath := filepath.Join(dir, "cert.pem")
+	keyPath := filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatalf("failed to write cert: %v", err)
+	}
+	if err := os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		t.Fatalf("failed to write key: %v", err)
+	}
+	return certPath, keyPath
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	dir := t.TempDir()
+	certPath, keyPath := writeTestCert(t, dir)
+
+	t.Run("Nil When Unset", func(t *testing.T) {
+		tlsCfg, err := newTLSConfig(tlsFiles{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if tlsCfg != nil {
+			t.Errorf("expected nil tls.Config when nothing is configured, got %+v", tlsCfg)
+		}
+	})
+
+	t.Run("CA, Client Pair and Server Name", func(t *testing.T) {
+		tlsCfg, err := newTLSConfig(tlsFiles{
+			CAFile:     certPath,
+			CertFile:   certPath,
+			KeyFile:    keyPath,
+			ServerName: "otel-collector.test",
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if tlsCfg.RootCAs == nil {
+			t.Error("expected RootCAs to be set")
+		}
+		if len(tlsCfg.Certificates) != 1 {
+			t.Errorf("expected 1 client certificate, got %d", len(tlsCfg.Certificates))
+		}
+		if tlsCfg.ServerName != "otel-collector.test" {
+			t.Errorf("expected ServerName override, got %q", tlsCfg.ServerName)
+		}
+	})
+
+	t.Run("Missing CA File", func(t *testing.T) {
+		if _, err := newTLSConfig(tlsFiles{CAFile: filepath.Join(dir, "missing.pem")}); err == nil {
+			t.Error("expected error for missing CA file")
+		}
+	})
+
+	t.Run("Invalid CA Content", func(t *testing.T) {
+		if _, err := newTLSConfig(tlsFiles{CAFile: keyPath}); err == nil {
+			t.Error("expected error for CA file without certificates")
+		}
+	})
+
+	t.Run("Certificate Without Key", func(t *testing.T) {
+		if _, err := newTLSConfig(tlsFiles{CertFile: certPath}); err == nil {
+			t.Error("expected error when client key is missing")
+		}
+	})
+}
+
+func TestNewServerTLSConfig(t *testing.T) {
+	certPath, keyPath := writeTestCert(t, t.TempDir())
+
+	tests := []struct {
+		name     string
+		files    tlsFiles
+		wantNil  bool
+		wantMTLS bool
+		wantErr  bool
+	}{
+		{name: "Nil When Unset", files: tlsFiles{}, wantNil: true},
+		{name: "Certificate And Key", files: tlsFiles{CertFile: certPath, KeyFile: keyPath}},
+		{name: "Client CA", files: tlsFiles{CAFile: certPath, CertFile: certPath, KeyFile: keyPath}, wantMTLS: true},
+		{name: "Client CA Without Certificate", files: tlsFiles{CAFile: certPath}, wantErr: true},
+		{name: "Certificate Without Key", files: tlsFiles{CertFile: certPath}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tlsCfg, err := newServerTLSConfig(tt.files)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newServerTLSConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if (tlsCfg == nil) != tt.wantNil {
+				t.Fatalf("expected nil config %v, got %+v", tt.wantNil, tlsCfg)
+			}
+			if tlsCfg != nil && (tlsCfg.ClientAuth == tls.RequireAndVerifyClientCert) != tt.wantMTLS {
+				t.Errorf("expected client verification %v, got %v", tt.wantMTLS, tlsCfg.ClientAuth)
+			}
+		})
+	}
+}
+
+func TestNewObservability_MetricsServerTLS(t *testing.T) {
+	certPath, keyPath := writeTestCert(t, t.TempDir())
+	o, err := NewObservability(BaseConfig{
+		ServiceName:           "test-otel-tls",
+		Version:               "1.0.0",
+		TracingDisabled:       true,
+		MetricsMode:           "pull",
+		MetricsHost:           "localhost",
+		MetricsPath:           "/metrics",
+		MetricsPort:           0,
+		MetricsAuthToken:      "s3cret",
+		MetricsServerCert:     certPath,
+		MetricsServerKey:      keyPath,
+		MetricsServerClientCA: certPath,
+		OtelDisableGlobals:    true,
+	})
+	if err != nil {
+		t.Fatalf("NewObservability failed: %v", err)
+	}
+	defer func() { _ = o.Shutdown(context.Background()) }()
+
+	pool, err := loadCertPool(certPath)
+	if err != nil {
+		t.Fatalf("loadCertPool failed: %v", err)
+	}
+	pair, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err != nil {
+		t.Fatalf("failed to load client certificate: %v", err)
+	}
+	_, port, _ := net.SplitHostPort(o.MetricsAddr())
+	url := "https://localhost:" + port + "/metrics"
+
+	scrape := func(clientCert bool) (int, error) {
+		tlsCfg := &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+		if clientCert {
+			tlsCfg.Certificates = []tls.Certificate{pair}
+		}
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsCfg}}
+		defer client.CloseIdleConnections()
+
+		req, _ := http.NewRequest(http.MethodGet, url, nil)
+		req.Header.Set("Authorization", "Bearer s3cret")
+		resp, err := client.Do(req)
+		if err != nil {
+			return 0, err
+		}
+		_ = resp.Body.Close()
+		return resp.StatusCode, nil
+	}
+
+	if code, err := scrape(true); err != nil || code != http.StatusOK {
+		t.Errorf("expected an mTLS scrape to succeed, got %d %v", code, err)
+	}
+	if _, err := scrape(false); err == nil {
+		t.Error("expected the handshake to fail without a client certificate")
+	}
+}
+
+func TestLoadCfgTLSValidation(t *testing.T) {
+	dir := t.TempDir()
+	certPath, keyPath := writeTestCert(t, dir)
+
+	envs := []string{"OTEL_CA_CERT", "OTEL_CLIENT_CERT", "OTEL_CLIENT_KEY", "METRICS_CA_CERT"}
+	defer func() {
+		for _, e := range envs {
+			_ = os.Unsetenv(e)
+		}
+	}()
+
+	_ = os.Unsetenv("LOG_LEVEL")
+	_ = os.Setenv("SERVICE_NAME", "tls-service")
+	_ = os.Setenv("METRICS_MODE", "pull")
+
+	t.Run("Valid Files", func(t *testing.T) {
+		_ = os.Setenv("OTEL_CA_CERT", certPath)
+		_ = os.Setenv("OTEL_CLIENT_CERT", certPath)
+		_ = os.Setenv("OTEL_CLIENT_KEY", keyPath)
+		defer func() {
+			for _, e := range envs {
+				_ = os.Unsetenv(e)
+			}
+		}()
+
+		var cfg BaseConfig
+		if err := LoadCfg(&cfg); err != nil {
+			t.Fatalf("LoadCfg failed: %v", err)
+		}
+		if cfg.OtelCACert != certPath {
+			t.Errorf("expected OtelCACert %s, got %s", certPath, cfg.OtelCACert)
+		}
+	})
+
+	t.Run("Missing CA File", func(t *testing.T) {
+		_ = os.Setenv("METRICS_CA_CERT", filepath.Join(dir, "missing.pem"))
+		defer func() { _ = os.Unsetenv("METRICS_CA_CERT") }()
+
+		var cfg BaseConfig
+		if err := LoadCfg(&cfg); err == nil {
+			t.Error("expected LoadCfg to fail for missing METRICS_CA_CERT file")
+		}
+	})
+
+	t.Run("Metrics Server Client CA Without Certificate", func(t *testing.T) {
+		_ = os.Setenv("METRICS_SERVER_CLIENT_CA", certPath)
+		defer func() { _ = os.Unsetenv("METRICS_SERVER_CLIENT_CA") }()
+
+		var cfg BaseConfig
+		if err := LoadCfg(&cfg); err == nil {
+			t.Error("expected LoadCfg to fail when METRICS_SERVER_CERT is missing")
+		}
+	})
+
+	t.Run("Metrics Server Certificate", func(t *testing.T) {
+		_ = os.Setenv("METRICS_SERVER_CERT", certPath)
+		_ = os.Setenv("METRICS_SERVER_KEY", keyPath)
+		defer func() {
+			_ = os.Unsetenv("METRICS_SERVER_CERT")
+			_ = os.Unsetenv("METRICS_SERVER_KEY")
+		}()
+
+		var cfg BaseConfig
+		if err := LoadCfg(&cfg); err != nil {
+			t.Fatalf("LoadCfg failed: %v", err)
+		}
+		if cfg.MetricsServerCert != certPath {
+			t.Errorf("expected MetricsServerCert %s, got %s", certPath, cfg.MetricsServerCert)
+		}
+	})
+
+	t.Run("Certificate Without Key", func(t *testing.T) {
+		_ = os.Setenv("OTEL_CLIENT_CERT", certPath)
+		defer func() { _ = os.Unsetenv("OTEL_CLIENT_CERT") }()
+
+		var cfg BaseConfig
+		if err := LoadCfg(&cfg); err == nil {
+			t.Error("expected LoadCfg to fail when OTEL_CLIENT_KEY is missing")
+		}
+	})
+}
+
+func TestInitOtelWithTLS(t *testing.T) {
+	certPath, keyPath := writeTestCert(t, t.TempDir())
+
+	for _, protocol := range []string{"http", "grpc"} {
+		t.Run(protocol, func(t *testing.T) {
+			cfg := BaseConfig{
+				ServiceName:           "test-otel-tls",
+				Version:               "1.0.0",
+				OtelEndpoint:          "localhost:4318",
+				TracesProtocol:        protocol,
+				OtelTracingSampleRate: 1.0,
+				MetricsMode:           "push",
+				MetricsPushEndpoint:   "localhost:4318",
+				MetricsPushInterval:   30,
+				MetricsProtocol:       protocol,
+				OtelCACert:            certPath,
+				OtelClientCert:        certPath,
+				OtelClientKey:         keyPath,
+				OtelTLSServerName:     "otel-collector.test",
+				MetricsCACert:         certPath,
+				MetricsClientCert:     certPath,
+				MetricsClientKey:      keyPath,
+			}
+
+			shutdown, err := InitOtel(cfg)
+			if err != nil {
+				t.Fatalf("InitOtel with TLS failed: %v", err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			_ = shutdown(ctx) // Ignore error as collector may not be running
+		})
+	}
+}
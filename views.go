@@ -0,0 +1,229 @@
+package observability
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// Histogram aggregations selectable with METRICS_HISTOGRAM_AGGREGATION
+const (
+	HistogramAggregationExplicit    = "explicit"
+	HistogramAggregationExponential = "exponential"
+)
+
+// Scale limits of base-2 exponential histograms
+const (
+	expHistogramMinScale = -10
+	expHistogramMaxScale = 20
+)
+
+// defaultExpHistogramMaxSize is the bucket count used when ExpHistogramMaxSize is 0
+const defaultExpHistogramMaxSize = 160
+
+// MetricView customizes how the instruments it matches are aggregated and exported
+type MetricView struct {
+	// Instrument matches instrument names; "*" and "?" wildcards are supported
+	Instrument string
+	// Meter optionally restricts the view to instruments of the meter (scope) with this name
+	Meter string
+	// Rename exports the instrument under a new name. Requires an Instrument without wildcards.
+	Rename string
+	// Buckets sets explicit histogram bucket boundaries, in the instrument's unit
+	Buckets []float64
+	// AllowAttributes keeps only these attribute keys
+	AllowAttributes []string
+	// DenyAttributes drops these attribute keys
+	DenyAttributes []string
+	// Drop stops the instrument from being exported at all
+	Drop bool
+}
+
+// MetricViews holds metric views parsed from the METRICS_VIEWS format, e.g.
+// "http.server.request.duration:buckets=0.05|0.1|0.25;rpc.*:deny=net.peer.port;debug.*:drop"
+type MetricViews []MetricView
+
+// SetValue parses the METRICS_VIEWS format (cleanenv Setter interface)
+func (m *MetricViews) SetValue(s string) error {
+	parsed, err := ParseMetricViews(s)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// ParseMetricViews parses semicolon-separated views of the form instrument[@meter]:option,... where
+// an option is buckets=b1|b2|..., allow=k1|k2|..., deny=k1|k2|..., rename=name or drop
+func ParseMetricViews(s string) (MetricViews, error) {
+	var views MetricViews
+	for i, entry := range strings.Split(s, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		selector, options, _ := strings.Cut(entry, ":")
+		instrument, meter, _ := strings.Cut(strings.TrimSpace(selector), "@")
+		view := MetricView{Instrument: strings.TrimSpace(instrument), Meter: strings.TrimSpace(meter)}
+		if view.Instrument == "" {
+			return nil, fmt.Errorf("invalid metric view #%d: missing instrument name", i+1)
+		}
+
+		for _, opt := range strings.Split(options, ",") {
+			opt = strings.TrimSpace(opt)
+			if opt == "" {
+				continue
+			}
+			key, value, _ := strings.Cut(opt, "=")
+			value = strings.TrimSpace(value)
+			switch strings.ToLower(strings.TrimSpace(key)) {
+			case "buckets":
+				for _, b := range splitViewList(value) {
+					f, err := strconv.ParseFloat(b, 64)
+					if err != nil {
+						return nil, fmt.Errorf("invalid metric view %q: bucket %q is not a number", view.Instrument, b)
+					}
+					view.Buckets = append(view.Buckets, f)
+				}
+			case "allow":
+				view.AllowAttributes = splitViewList(value)
+			case "deny":
+				view.DenyAttributes = splitViewList(value)
+			case "rename":
+				view.Rename = value
+			case "drop":
+				view.Drop = true
+			default:
+				return nil, fmt.Errorf("invalid metric view %q: unknown option %q (must be buckets, allow, deny, rename or drop)", view.Instrument, key)
+			}
+		}
+		views = append(views, view)
+	}
+	return views, nil
+}
+
+// splitViewList splits a "|"-separated option value, skipping empty items
+func splitViewList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, "|") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Validate checks that the view can be applied
+func (v MetricView) Validate() error {
+	if strings.TrimSpace(v.Instrument) == "" {
+		return fmt.Errorf("metric view: missing instrument name")
+	}
+	if v.Rename != "" && strings.ContainsAny(v.Instrument, "*?") {
+		return fmt.Errorf("metric view %q: rename requires an instrument name without wildcards", v.Instrument)
+	}
+	if len(v.AllowAttributes) > 0 && len(v.DenyAttributes) > 0 {
+		return fmt.Errorf("metric view %q: allow and deny cannot be combined", v.Instrument)
+	}
+	if !sort.Float64sAreSorted(v.Buckets) {
+		return fmt.Errorf("metric view %q: buckets must be in increasing order", v.Instrument)
+	}
+	for i := 1; i < len(v.Buckets); i++ {
+		if v.Buckets[i] == v.Buckets[i-1] {
+			return fmt.Errorf("metric view %q: duplicate bucket %v", v.Instrument, v.Buckets[i])
+		}
+	}
+	return nil
+}
+
+// Validate checks every view
+func (m MetricViews) Validate() error {
+	for _, v := range m {
+		if err := v.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sdkView converts the view to an SDK view
+func (v MetricView) sdkView() (sdkmetric.View, error) {
+	if err := v.Validate(); err != nil {
+		return nil, err
+	}
+
+	criteria := sdkmetric.Instrument{Name: v.Instrument}
+	if v.Meter != "" {
+		criteria.Scope = instrumentation.Scope{Name: v.Meter}
+	}
+
+	var stream sdkmetric.Stream
+	switch {
+	case v.Drop:
+		stream.Aggregation = sdkmetric.AggregationDrop{}
+	case len(v.Buckets) > 0:
+		stream.Aggregation = sdkmetric.AggregationExplicitBucketHistogram{Boundaries: v.Buckets}
+	}
+	stream.Name = v.Rename
+	if len(v.AllowAttributes) > 0 {
+		stream.AttributeFilter = attribute.NewAllowKeysFilter(attributeKeys(v.AllowAttributes)...)
+	} else if len(v.DenyAttributes) > 0 {
+		stream.AttributeFilter = attribute.NewDenyKeysFilter(attributeKeys(v.DenyAttributes)...)
+	}
+	return sdkmetric.NewView(criteria, stream), nil
+}
+
+// sdkViews converts the views to SDK views, in order
+func (m MetricViews) sdkViews() ([]sdkmetric.View, error) {
+	views := make([]sdkmetric.View, 0, len(m))
+	for _, v := range m {
+		view, err := v.sdkView()
+		if err != nil {
+			return nil, err
+		}
+		views = append(views, view)
+	}
+	return views, nil
+}
+
+// attributeKeys converts attribute names to keys
+func attributeKeys(names []string) []attribute.Key {
+	keys := make([]attribute.Key, 0, len(names))
+	for _, n := range names {
+		keys = append(keys, attribute.Key(n))
+	}
+	return keys
+}
+
+// isExponentialHistograms returns true if histograms use base-2 exponential aggregation
+func (b *BaseConfig) isExponentialHistograms() bool {
+	return strings.ToLower(strings.TrimSpace(b.HistogramAggregation)) == HistogramAggregationExponential
+}
+
+// histogramAggregationSelector returns the reader aggregation selector for
+// METRICS_HISTOGRAM_AGGREGATION, or nil to keep explicit bucket histograms. Views setting buckets
+// still take precedence over it.
+func (b *BaseConfig) histogramAggregationSelector() sdkmetric.AggregationSelector {
+	if !b.isExponentialHistograms() {
+		return nil
+	}
+	maxSize := b.ExpHistogramMaxSize
+	if maxSize <= 0 {
+		maxSize = defaultExpHistogramMaxSize
+	}
+	agg := sdkmetric.AggregationBase2ExponentialHistogram{
+		MaxSize:  int32(maxSize),
+		MaxScale: int32(b.ExpHistogramMaxScale),
+	}
+	return func(kind sdkmetric.InstrumentKind) sdkmetric.Aggregation {
+		if kind == sdkmetric.InstrumentKindHistogram {
+			return agg
+		}
+		return sdkmetric.DefaultAggregationSelector(kind)
+	}
+}
@@ -0,0 +1,306 @@
+package observability
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestParseMetricViews(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    MetricViews
+		wantErr bool
+	}{
+		{name: "Empty", input: "", want: nil},
+		{
+			name:  "All Options",
+			input: "http.server.request.duration:buckets=0.05|0.1|0.25, deny=net.peer.port ; legacy_total@orders:rename=orders_total,allow=status|method;debug.*:drop",
+			want: MetricViews{
+				{Instrument: "http.server.request.duration", Buckets: []float64{0.05, 0.1, 0.25}, DenyAttributes: []string{"net.peer.port"}},
+				{Instrument: "legacy_total", Meter: "orders", Rename: "orders_total", AllowAttributes: []string{"status", "method"}},
+				{Instrument: "debug.*", Drop: true},
+			},
+		},
+		{name: "Missing Instrument", input: ":drop", wantErr: true},
+		{name: "Invalid Bucket", input: "latency:buckets=0.1|fast", wantErr: true},
+		{name: "Unknown Option", input: "latency:sum=true", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMetricViews(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseMetricViews() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseMetricViews() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMetricViewValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		view    MetricView
+		wantErr bool
+	}{
+		{name: "Valid", view: MetricView{Instrument: "latency", Buckets: []float64{0.05, 0.1, 0.25}}, wantErr: false},
+		{name: "Rename With Wildcard", view: MetricView{Instrument: "http.*", Rename: "http"}, wantErr: true},
+		{name: "Allow And Deny", view: MetricView{Instrument: "latency", AllowAttributes: []string{"a"}, DenyAttributes: []string{"b"}}, wantErr: true},
+		{name: "Unsorted Buckets", view: MetricView{Instrument: "latency", Buckets: []float64{0.25, 0.1}}, wantErr: true},
+		{name: "Duplicate Buckets", view: MetricView{Instrument: "latency", Buckets: []float64{0.1, 0.1}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.view.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMetricViewsApplied(t *testing.T) {
+	views, err := MetricViews{
+		{Instrument: "request.duration", Buckets: []float64{0.05, 0.1, 0.25}, DenyAttributes: []string{"peer"}},
+		{Instrument: "legacy_total", Meter: "orders", Rename: "orders_total"},
+		{Instrument: "debug.*", Drop: true},
+	}.sdkViews()
+	if err != nil {
+		t.Fatalf("sdkViews failed: %v", err)
+	}
+
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader), sdkmetric.WithView(views...))
+	defer func() { _ = mp.Shutdown(context.Background()) }()
+
+	ctx := context.Background()
+	meter := mp.Meter("orders")
+	duration, _ := meter.Float64Histogram("request.duration")
+	duration.Record(ctx, 0.08, metric.WithAttributes(attribute.String("route", "/orders"), attribute.String("peer", "10.0.0.1")))
+	legacy, _ := meter.Int64Counter("legacy_total")
+	legacy.Add(ctx, 2)
+	debug, _ := meter.Int64Counter("debug.cache_probes")
+	debug.Add(ctx, 1)
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	got := map[string]metricdata.Aggregation{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			got[m.Name] = m.Data
+		}
+	}
+
+	hist, ok := got["request.duration"].(metricdata.Histogram[float64])
+	if !ok || len(hist.DataPoints) != 1 {
+		t.Fatalf("expected one request.duration data point, got %+v", got["request.duration"])
+	}
+	dp := hist.DataPoints[0]
+	if !reflect.DeepEqual(dp.Bounds, []float64{0.05, 0.1, 0.25}) || !reflect.DeepEqual(dp.BucketCounts, []uint64{0, 1, 0, 0}) {
+		t.Errorf("expected custom buckets, got bounds %v counts %v", dp.Bounds, dp.BucketCounts)
+	}
+	if _, ok := dp.Attributes.Value("peer"); ok {
+		t.Error("expected the denied peer attribute to be dropped")
+	}
+	if _, ok := dp.Attributes.Value("route"); !ok {
+		t.Error("expected the route attribute to be kept")
+	}
+	if _, ok := got["orders_total"]; !ok {
+		t.Errorf("expected legacy_total to be renamed, got %v", reflect.ValueOf(got).MapKeys())
+	}
+	if _, ok := got["debug.cache_probes"]; ok {
+		t.Error("expected debug.* instruments to be dropped")
+	}
+}
+
+func TestLoadCfgMetricsViews(t *testing.T) {
+	defer func() { _ = os.Unsetenv("METRICS_VIEWS") }()
+
+	_ = os.Unsetenv("LOG_LEVEL")
+	_ = os.Setenv("SERVICE_NAME", "views-service")
+	_ = os.Setenv("METRICS_MODE", "pull")
+
+	tests := []struct {
+		name    string
+		views   string
+		want    int
+		wantErr bool
+	}{
+		{name: "Buckets And Drop", views: "http.server.request.duration:buckets=0.05|0.1|0.25;debug.*:drop", want: 2, wantErr: false},
+		{name: "Syntax Error", views: "latency:sum=true", wantErr: true},
+		{name: "Unsorted Buckets", views: "latency:buckets=0.25|0.1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_ = os.Setenv("METRICS_VIEWS", tt.views)
+
+			var cfg BaseConfig
+			err := LoadCfg(&cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadCfg() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && len(cfg.MetricsViews) != tt.want {
+				t.Errorf("expected %d views, got %+v", tt.want, cfg.MetricsViews)
+			}
+		})
+	}
+}
+
+func TestHistogramAggregationSelector(t *testing.T) {
+	if sel := (&BaseConfig{HistogramAggregation: "explicit"}).histogramAggregationSelector(); sel != nil {
+		t.Error("expected no selector for explicit histograms")
+	}
+
+	tests := []struct {
+		name string
+		cfg  BaseConfig
+		want sdkmetric.AggregationBase2ExponentialHistogram
+	}{
+		{
+			name: "Configured",
+			cfg:  BaseConfig{HistogramAggregation: "Exponential", ExpHistogramMaxSize: 80, ExpHistogramMaxScale: 10},
+			want: sdkmetric.AggregationBase2ExponentialHistogram{MaxSize: 80, MaxScale: 10},
+		},
+		{
+			name: "Default Size",
+			cfg:  BaseConfig{HistogramAggregation: "exponential", ExpHistogramMaxScale: 20},
+			want: sdkmetric.AggregationBase2ExponentialHistogram{MaxSize: 160, MaxScale: 20},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sel := tt.cfg.histogramAggregationSelector()
+			if sel == nil {
+				t.Fatal("expected a selector for exponential histograms")
+			}
+			if got := sel(sdkmetric.InstrumentKindHistogram); got != tt.want {
+				t.Errorf("expected %+v for histograms, got %+v", tt.want, got)
+			}
+			if _, ok := sel(sdkmetric.InstrumentKindCounter).(sdkmetric.AggregationSum); !ok {
+				t.Error("expected counters to keep the sum aggregation")
+			}
+		})
+	}
+}
+
+func TestNewObservability_NativeHistograms(t *testing.T) {
+	o, err := NewObservability(BaseConfig{
+		ServiceName:          "test-otel-native",
+		Version:              "1.0.0",
+		TracingDisabled:      true,
+		MetricsMode:          "pull",
+		MetricsPath:          "/metrics",
+		MetricsPort:          0,
+		HistogramAggregation: "exponential",
+		ExpHistogramMaxScale: 20,
+		OtelDisableGlobals:   true,
+	})
+	if err != nil {
+		t.Fatalf("NewObservability failed: %v", err)
+	}
+	defer func() { _ = o.Shutdown(context.Background()) }()
+
+	latency, _ := o.Meter("native").Float64Histogram("checkout.latency", metric.WithUnit("s"))
+	for _, v := range []float64{0.04, 0.09, 0.2} {
+		latency.Record(context.Background(), v)
+	}
+
+	families, err := o.Registry.Gather()
+	if err != nil {
+		t.Fatalf("gather failed: %v", err)
+	}
+	for _, f := range families {
+		if f.GetName() != "checkout_latency_seconds" {
+			continue
+		}
+		h := f.GetMetric()[0].GetHistogram()
+		if h.Schema == nil || h.GetSampleCount() != 3 {
+			t.Errorf("expected a native histogram with 3 samples, got %v", h)
+		}
+		return
+	}
+	t.Error("expected checkout_latency_seconds in the registry")
+}
+
+func TestFileMetricReaderExponentialHistograms(t *testing.T) {
+	dir := t.TempDir()
+	reader, err := newFileMetricReader(context.Background(), BaseConfig{
+		FileExportDir:        dir,
+		MetricsPushInterval:  30,
+		HistogramAggregation: "exponential",
+		ExpHistogramMaxSize:  40,
+		ExpHistogramMaxScale: 5,
+	}, metricPipeline{})
+	if err != nil {
+		t.Fatalf("newFileMetricReader failed: %v", err)
+	}
+
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	latency, _ := mp.Meter("native").Float64Histogram("checkout.latency")
+	latency.Record(context.Background(), 0.1)
+	if err := mp.Shutdown(context.Background()); err != nil {
+		t.Fatalf("meter provider shutdown failed: %v", err)
+	}
+
+	lines := readLines(t, filepath.Join(dir, "metrics.jsonl"))
+	var req colmetricpb.ExportMetricsServiceRequest
+	if len(lines) != 1 || protojson.Unmarshal([]byte(lines[0]), &req) != nil {
+		t.Fatalf("expected one OTLP JSON line, got %v", lines)
+	}
+	hist := req.ResourceMetrics[0].ScopeMetrics[0].Metrics[0].GetExponentialHistogram()
+	if hist == nil || hist.DataPoints[0].Scale != 5 {
+		t.Errorf("expected an OTLP exponential histogram at scale 5, got %v", req.ResourceMetrics[0].ScopeMetrics[0].Metrics[0])
+	}
+}
+
+func TestLoadCfgHistogramAggregation(t *testing.T) {
+	defer func() {
+		_ = os.Unsetenv("METRICS_HISTOGRAM_AGGREGATION")
+		_ = os.Unsetenv("METRICS_EXPONENTIAL_HISTOGRAM_MAX_SCALE")
+	}()
+
+	_ = os.Unsetenv("LOG_LEVEL")
+	_ = os.Setenv("SERVICE_NAME", "histogram-service")
+	_ = os.Setenv("METRICS_MODE", "pull")
+
+	tests := []struct {
+		name        string
+		aggregation string
+		scale       string
+		wantErr     bool
+	}{
+		{name: "Exponential", aggregation: "exponential", scale: "8", wantErr: false},
+		{name: "Invalid Aggregation", aggregation: "native", scale: "20", wantErr: true},
+		{name: "Scale Too Large", aggregation: "exponential", scale: "21", wantErr: true},
+		{name: "Scale Too Small", aggregation: "exponential", scale: "-11", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_ = os.Setenv("METRICS_HISTOGRAM_AGGREGATION", tt.aggregation)
+			_ = os.Setenv("METRICS_EXPONENTIAL_HISTOGRAM_MAX_SCALE", tt.scale)
+
+			var cfg BaseConfig
+			if err := LoadCfg(&cfg); (err != nil) != tt.wantErr {
+				t.Errorf("LoadCfg() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}